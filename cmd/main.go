@@ -106,13 +106,13 @@ func main() {
 
 	// Create a B-Tree with minimum degree 2 (2-3-4 tree)
 	fmt.Println("   🌳 Creating B-Tree with minimum degree t=2:")
-	bt := btree.NewBTree(2)
+	bt := btree.NewBTree[int, string](2)
 
 	// Insert keys
 	keysToInsert := []int{10, 20, 5, 6, 12, 30, 7, 17, 3, 8}
 	fmt.Printf("   Inserting keys: %v\n", keysToInsert)
 	for _, k := range keysToInsert {
-		bt.Insert(k)
+		bt.Insert(k, fmt.Sprintf("value-%d", k))
 	}
 	fmt.Println("   ✅ All keys inserted!")
 	fmt.Println()
@@ -121,8 +121,8 @@ func main() {
 	fmt.Println("   🔍 Searching keys:")
 	searchKeys := []int{6, 15, 30, 100}
 	for _, k := range searchKeys {
-		if bt.Search(k) {
-			fmt.Printf("   Key %d: ✅ found\n", k)
+		if v, ok := bt.Get(k); ok {
+			fmt.Printf("   Key %d: ✅ found (%s)\n", k, v)
 		} else {
 			fmt.Printf("   Key %d: ❌ not found\n", k)
 		}
@@ -152,13 +152,20 @@ func main() {
 
 	// Example with larger minimum degree (more efficient for high volumes)
 	fmt.Println("   📊 Example with minimum degree t=50 (typical for databases):")
-	btLarge := btree.NewBTree(50)
-
-	// Insert 1000 keys
-	for i := 1; i <= 1000; i++ {
-		btLarge.Insert(i)
+	// Sorted keys are bulk loaded bottom-up instead of inserted one by one,
+	// which avoids repeated splits and packs the nodes full
+	btLarge, err := btree.BulkLoad(50, 1, func(yield func(int, int) bool) {
+		for i := 1; i <= 1000; i++ {
+			if !yield(i, i*i) {
+				return
+			}
+		}
+	})
+	if err != nil {
+		fmt.Printf("   ❌ bulk load failed: %v\n", err)
+		return
 	}
-	fmt.Println("   ✅ 1000 keys inserted!")
+	fmt.Printf("   ✅ %d keys bulk loaded, height %d!\n", btLarge.Len(), btLarge.Height())
 
 	// Check a few keys
 	testKeys := []int{1, 500, 1000, 1001}
@@ -173,25 +180,26 @@ func main() {
 
 	// Typical use case: database index
 	fmt.Println("   💾 Database index simulation:")
-	dbIndex := btree.NewBTree(100)
+	dbIndex := btree.NewBTree[int, string](100)
 
-	// Simulate record IDs
+	// Simulate record IDs mapped to their row locations
 	recordIDs := []int{1001, 2045, 3089, 4023, 5067, 6011, 7055, 8099}
 	fmt.Printf("   Indexing records: %v\n", recordIDs)
-	for _, id := range recordIDs {
-		dbIndex.Insert(id)
+	for i, id := range recordIDs {
+		dbIndex.Put(id, fmt.Sprintf("page %d, slot %d", i/4, i%4))
 	}
 
 	// Search a record
 	searchID := 3089
-	if dbIndex.Search(searchID) {
-		fmt.Printf("   Record #%d: ✅ found in index\n", searchID)
+	if location, ok := dbIndex.Get(searchID); ok {
+		fmt.Printf("   Record #%d: ✅ found in index at %s\n", searchID, location)
 	}
 
 	// Remove a deleted record
 	deleteID := 2045
-	dbIndex.Remove(deleteID)
-	fmt.Printf("   Record #%d removed from index\n", deleteID)
+	if location, ok := dbIndex.Delete(deleteID); ok {
+		fmt.Printf("   Record #%d removed from index (was at %s)\n", deleteID, location)
+	}
 
 	if !dbIndex.Search(deleteID) {
 		fmt.Printf("   Record #%d: ❌ no longer in index\n", deleteID)
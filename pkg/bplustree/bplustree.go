@@ -0,0 +1,539 @@
+// Package bplustree implements a B+ Tree, the variant of the B-Tree used by
+// most database indexes for range-scan heavy workloads.
+//
+// A B+ Tree of minimum degree t has the following properties:
+//   - Every node has at most 2t-1 keys
+//   - Every node (except root) has at least t-1 keys
+//   - All leaves appear at the same level
+//   - An internal node with k keys has k+1 children
+//
+// Unlike a B-Tree, every key-value pair lives in a leaf. Internal nodes hold
+// separator keys only: all keys in childs[i] are less than keys[i], and all
+// keys in childs[i+1] are greater than or equal to it. Each leaf is linked to
+// its neighbours, so ordered scans walk the leaf chain without climbing back
+// up the tree.
+//
+// The API mirrors package btree, so the two trees can be swapped for one
+// another and compared.
+//
+// Time complexity for all operations: O(log n)
+// Space complexity: O(n)
+package bplustree
+
+import (
+	"cmp"
+	"slices"
+)
+
+// BPlusTreeNode represents a single node in the B+ Tree.
+// Leaves hold keys and their values and are chained in key order; internal
+// nodes hold separator keys and pointers to child nodes.
+type BPlusTreeNode[K, V any] struct {
+	leaf   bool                   // Indicates whether this node is a leaf (has no children)
+	keys   []K                    // Keys in sorted order: stored keys in leaves, separators in internal nodes
+	values []V                    // values[i] is the value stored under keys[i] (leaves only)
+	childs []*BPlusTreeNode[K, V] // Slice of pointers to child nodes (empty if leaf is true)
+	prev   *BPlusTreeNode[K, V]   // Previous leaf in key order (leaves only)
+	next   *BPlusTreeNode[K, V]   // Next leaf in key order (leaves only)
+}
+
+// BPlusTree represents a B+ Tree data structure with a specified minimum
+// degree. The minimum degree t determines the range of keys each node can
+// hold.
+type BPlusTree[K, V any] struct {
+	root    *BPlusTreeNode[K, V] // Pointer to the root node of the tree (nil if tree is empty)
+	t       int                  // Minimum degree: each node can have [t-1, 2t-1] keys (except root)
+	size    int                  // Number of keys stored in the tree
+	compare func(a, b K) int     // Orders keys: negative if a < b, zero if equal, positive if a > b
+}
+
+// NewBPlusTree creates and returns a new empty B+ Tree with the specified
+// minimum degree t, ordering keys with cmp.Compare.
+// The minimum degree determines the capacity of each node:
+//   - Each node can hold between t-1 and 2t-1 keys
+//   - Each internal node can have between t and 2t children
+//
+// Parameters:
+//   - t: The minimum degree of the B+ Tree (values below 2 are raised to 2)
+//
+// Returns:
+//   - *BPlusTree[K, V]: A pointer to the newly created empty B+ Tree
+//
+// Time complexity: O(1)
+// Space complexity: O(1)
+//
+// Example:
+//
+//	bt := bplustree.NewBPlusTree[int, string](3)
+//	bt.Put(10, "ten")
+//	bt.Put(20, "twenty")
+//	bt.Put(5, "five")
+func NewBPlusTree[K cmp.Ordered, V any](t int) *BPlusTree[K, V] {
+	return NewBPlusTreeFunc[K, V](t, cmp.Compare[K])
+}
+
+// NewBPlusTreeFunc creates and returns a new empty B+ Tree with the
+// specified minimum degree t, ordering keys with compare. Like cmp.Compare,
+// compare must return a negative number if a < b, zero if a == b and a
+// positive number if a > b, and it must define a strict weak ordering.
+//
+// Parameters:
+//   - t: The minimum degree of the B+ Tree (values below 2 are raised to 2)
+//   - compare: The key comparator; nil panics on first use
+//
+// Returns:
+//   - *BPlusTree[K, V]: A pointer to the newly created empty B+ Tree
+//
+// Time complexity: O(1)
+// Space complexity: O(1)
+//
+// Example:
+//
+//	// Case-insensitive string keys
+//	bt := bplustree.NewBPlusTreeFunc[string, int](2, func(a, b string) int {
+//		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+//	})
+func NewBPlusTreeFunc[K, V any](t int, compare func(a, b K) int) *BPlusTree[K, V] {
+	return &BPlusTree[K, V]{t: max(t, 2), compare: compare}
+}
+
+// Put stores value under key while maintaining all B+ Tree properties.
+// If the key already exists, its value is replaced.
+//
+// The insertion process:
+//  1. If the tree is empty, create a new leaf root with the key
+//  2. If the root is full (has 2t-1 keys), split it and create a new root
+//  3. Descend to the leaf, splitting every full node on the way down
+//  4. Insert the key into the leaf in sorted order
+//
+// Parameters:
+//   - key: The key to store
+//   - value: The value to store under key
+//
+// Returns:
+//   - V: The value previously stored under key, or the zero value
+//   - bool: true if key was already present and its value was replaced
+//
+// Time complexity: O(t * log_t(n)) where t is the minimum degree and n is the number of keys
+// Space complexity: O(1)
+//
+// Example:
+//
+//	bt := bplustree.NewBPlusTree[int, string](2)
+//	bt.Put(10, "ten")
+//	old, replaced := bt.Put(10, "TEN")  // returns "ten", true
+func (bt *BPlusTree[K, V]) Put(key K, value V) (V, bool) {
+	if bt.root == nil {
+		bt.root = &BPlusTreeNode[K, V]{leaf: true, keys: []K{key}, values: []V{value}}
+		bt.size = 1
+		var zero V
+		return zero, false
+	}
+
+	// If the root is full, the tree grows in height
+	if len(bt.root.keys) == 2*bt.t-1 {
+		oldRoot := bt.root
+		bt.root = &BPlusTreeNode[K, V]{childs: []*BPlusTreeNode[K, V]{oldRoot}}
+		bt.splitChild(bt.root, 0)
+	}
+
+	node := bt.root
+	for !node.leaf {
+		i := bt.childIndex(node, key)
+		if len(node.childs[i].keys) == 2*bt.t-1 {
+			bt.splitChild(node, i)
+			// Keys equal to the new separator belong to its right
+			if bt.compare(key, node.keys[i]) >= 0 {
+				i++
+			}
+		}
+		node = node.childs[i]
+	}
+
+	i, found := bt.find(node, key)
+	if found {
+		old := node.values[i]
+		node.values[i] = value
+		return old, true
+	}
+	node.keys = slices.Insert(node.keys, i, key)
+	node.values = slices.Insert(node.values, i, value)
+	bt.size++
+	var zero V
+	return zero, false
+}
+
+// Insert stores value under key, replacing the value of an existing key.
+// It is Put without the previous value.
+//
+// Parameters:
+//   - key: The key to store
+//   - value: The value to store under key
+//
+// Time complexity: O(t * log_t(n)) where t is the minimum degree and n is the number of keys
+// Space complexity: O(1)
+//
+// Example:
+//
+//	bt := bplustree.NewBPlusTree[int, string](2)
+//	bt.Insert(10, "ten")
+//	bt.Insert(20, "twenty")
+//	bt.Insert(5, "five")
+//	// Tree now contains: 5, 10, 20
+func (bt *BPlusTree[K, V]) Insert(key K, value V) {
+	bt.Put(key, value)
+}
+
+// splitChild splits the full child at index i of parent into two nodes.
+//
+// A leaf keeps its first t pairs and moves the last t-1 to a new leaf, which
+// is linked in after it; the first key of the new leaf is copied up to the
+// parent as a separator. An internal node keeps its first t-1 separators,
+// moves the last t-1 to a new node, and moves the middle one up to the
+// parent.
+//
+// Parameters:
+//   - parent: The non-full parent of the node to split
+//   - i: The index in parent's children of the full node
+//
+// Time complexity: O(t) where t is the minimum degree
+// Space complexity: O(t) for the new node
+func (bt *BPlusTree[K, V]) splitChild(parent *BPlusTreeNode[K, V], i int) {
+	t := bt.t
+	full := parent.childs[i]
+	right := &BPlusTreeNode[K, V]{leaf: full.leaf}
+
+	var separator K
+	if full.leaf {
+		right.keys = append(right.keys, full.keys[t:]...)
+		right.values = append(right.values, full.values[t:]...)
+		clear(full.keys[t:])
+		clear(full.values[t:])
+		full.keys = full.keys[:t]
+		full.values = full.values[:t]
+		separator = right.keys[0]
+
+		// Link the new leaf in after the full one
+		right.prev, right.next = full, full.next
+		if full.next != nil {
+			full.next.prev = right
+		}
+		full.next = right
+	} else {
+		right.keys = append(right.keys, full.keys[t:]...)
+		right.childs = append(right.childs, full.childs[t:]...)
+		separator = full.keys[t-1]
+		clear(full.keys[t-1:])
+		clear(full.childs[t:])
+		full.keys = full.keys[:t-1]
+		full.childs = full.childs[:t]
+	}
+
+	parent.keys = slices.Insert(parent.keys, i, separator)
+	parent.childs = slices.Insert(parent.childs, i+1, right)
+}
+
+// find returns the index of the first key in node that is not less than key,
+// and whether that key equals key.
+//
+// Time complexity: O(log t) by binary search
+// Space complexity: O(1)
+func (bt *BPlusTree[K, V]) find(node *BPlusTreeNode[K, V], key K) (int, bool) {
+	return slices.BinarySearchFunc(node.keys, key, bt.compare)
+}
+
+// childIndex returns the index of the child of the internal node whose
+// subtree holds key. Keys equal to a separator live to its right.
+//
+// Time complexity: O(log t) by binary search
+// Space complexity: O(1)
+func (bt *BPlusTree[K, V]) childIndex(node *BPlusTreeNode[K, V], key K) int {
+	i, found := bt.find(node, key)
+	if found {
+		i++
+	}
+	return i
+}
+
+// findLeaf returns the leaf whose key range covers key, or nil if the tree
+// is empty.
+//
+// Time complexity: O(log t * log_t(n))
+// Space complexity: O(1)
+func (bt *BPlusTree[K, V]) findLeaf(key K) *BPlusTreeNode[K, V] {
+	node := bt.root
+	for node != nil && !node.leaf {
+		node = node.childs[bt.childIndex(node, key)]
+	}
+	return node
+}
+
+// Get returns the value stored under key. Every search ends in a leaf, even
+// when key matches a separator on the way down.
+//
+// Parameters:
+//   - key: The key to look up
+//
+// Returns:
+//   - V: The value stored under key, or the zero value
+//   - bool: true if the key exists in the tree, false otherwise
+//
+// Time complexity: O(log t * log_t(n)) where t is the minimum degree
+// Space complexity: O(1)
+//
+// Example:
+//
+//	bt := bplustree.NewBPlusTree[int, string](2)
+//	bt.Put(10, "ten")
+//	v, ok := bt.Get(10)  // returns "ten", true
+//	v, ok = bt.Get(15)   // returns "", false
+func (bt *BPlusTree[K, V]) Get(key K) (V, bool) {
+	if leaf := bt.findLeaf(key); leaf != nil {
+		if i, found := bt.find(leaf, key); found {
+			return leaf.values[i], true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Search checks whether a key exists in the B+ Tree.
+//
+// Parameters:
+//   - key: The key to search for
+//
+// Returns:
+//   - bool: true if the key exists in the tree, false otherwise
+//
+// Time complexity: O(log t * log_t(n)) where t is the minimum degree
+// Space complexity: O(1)
+//
+// Example:
+//
+//	bt := bplustree.NewBPlusTree[int, string](2)
+//	bt.Insert(10, "ten")
+//	bt.Search(10)  // returns true
+//	bt.Search(15)  // returns false
+func (bt *BPlusTree[K, V]) Search(key K) bool {
+	_, ok := bt.Get(key)
+	return ok
+}
+
+// Delete removes a key from the B+ Tree and returns its value.
+//
+// The deletion process descends from the root to the leaf holding the key.
+// Before entering a child with only t-1 keys, it borrows a key from a
+// sibling or merges the child with one, so that the leaf can always give up
+// a key. Separators are left in place even when the key they were copied
+// from is deleted: they still divide the key ranges of their children.
+//
+// Parameters:
+//   - key: The key to remove
+//
+// Returns:
+//   - V: The removed value, or the zero value
+//   - bool: true if the key was found and removed
+//
+// Time complexity: O(t * log_t(n)) where t is the minimum degree
+// Space complexity: O(1)
+//
+// Example:
+//
+//	bt := bplustree.NewBPlusTree[int, string](2)
+//	bt.Put(10, "ten")
+//	bt.Put(20, "twenty")
+//	v, ok := bt.Delete(10)  // returns "ten", true
+//	// Tree now contains: 20
+func (bt *BPlusTree[K, V]) Delete(key K) (V, bool) {
+	var zero V
+	if bt.root == nil {
+		return zero, false
+	}
+
+	node := bt.root
+	for !node.leaf {
+		i := bt.childIndex(node, key)
+		if len(node.childs[i].keys) < bt.t {
+			i = bt.fill(node, i)
+		}
+		next := node.childs[i]
+
+		// A merge may have emptied the root
+		if node == bt.root && len(node.keys) == 0 {
+			bt.root = next
+		}
+		node = next
+	}
+
+	i, found := bt.find(node, key)
+	if !found {
+		return zero, false
+	}
+	value := node.values[i]
+	node.keys = slices.Delete(node.keys, i, i+1)
+	node.values = slices.Delete(node.values, i, i+1)
+	bt.size--
+	if bt.size == 0 {
+		bt.root = nil
+	}
+	return value, true
+}
+
+// Remove deletes a key from the B+ Tree. It is Delete without the removed
+// value; if the key does not exist in the tree, nothing happens.
+//
+// Parameters:
+//   - key: The key to remove from the tree
+//
+// Time complexity: O(t * log_t(n)) where t is the minimum degree
+// Space complexity: O(1)
+//
+// Example:
+//
+//	bt := bplustree.NewBPlusTree[int, string](2)
+//	bt.Insert(10, "ten")
+//	bt.Insert(20, "twenty")
+//	bt.Insert(5, "five")
+//	bt.Remove(10)  // Removes 10 from the tree
+//	// Tree now contains: 5, 20
+func (bt *BPlusTree[K, V]) Remove(key K) {
+	bt.Delete(key)
+}
+
+// fill ensures the child at index idx of node has at least t keys before
+// the deletion descends into it.
+//
+// Three strategies are tried in order:
+//  1. Borrow a key from the left sibling if it has >= t keys
+//  2. Borrow a key from the right sibling if it has >= t keys
+//  3. Merge with a sibling (right sibling if exists, otherwise left)
+//
+// Parameters:
+//   - node: The parent node
+//   - idx: The index of the child that needs filling
+//
+// Returns:
+//   - int: The index of the child that now covers the keys of the old child
+//
+// Time complexity: O(t)
+// Space complexity: O(1)
+func (bt *BPlusTree[K, V]) fill(node *BPlusTreeNode[K, V], idx int) int {
+	switch {
+	case idx > 0 && len(node.childs[idx-1].keys) >= bt.t:
+		bt.borrowFromPrev(node, idx)
+	case idx < len(node.keys) && len(node.childs[idx+1].keys) >= bt.t:
+		bt.borrowFromNext(node, idx)
+	case idx < len(node.keys):
+		bt.merge(node, idx)
+	default:
+		bt.merge(node, idx-1)
+		return idx - 1
+	}
+	return idx
+}
+
+// borrowFromPrev moves the last key of the left sibling into the child at
+// index idx.
+//
+// For leaves the pair moves across and becomes the child's first key, which
+// is then the new separator. For internal nodes the separator moves down to
+// the child, the sibling's last key moves up to replace it, and the
+// sibling's last child moves across with it.
+//
+// Parameters:
+//   - node: The parent node
+//   - idx: The index of the child receiving the key
+//
+// Time complexity: O(t)
+// Space complexity: O(1)
+func (bt *BPlusTree[K, V]) borrowFromPrev(node *BPlusTreeNode[K, V], idx int) {
+	child, sibling := node.childs[idx], node.childs[idx-1]
+	last := len(sibling.keys) - 1
+
+	if child.leaf {
+		child.keys = slices.Insert(child.keys, 0, sibling.keys[last])
+		child.values = slices.Insert(child.values, 0, sibling.values[last])
+		node.keys[idx-1] = child.keys[0]
+	} else {
+		child.keys = slices.Insert(child.keys, 0, node.keys[idx-1])
+		child.childs = slices.Insert(child.childs, 0, sibling.childs[last+1])
+		node.keys[idx-1] = sibling.keys[last]
+		sibling.childs[last+1] = nil
+		sibling.childs = sibling.childs[:last+1]
+	}
+
+	var zero K
+	sibling.keys[last] = zero
+	sibling.keys = sibling.keys[:last]
+	if sibling.leaf {
+		var zeroValue V
+		sibling.values[last] = zeroValue
+		sibling.values = sibling.values[:last]
+	}
+}
+
+// borrowFromNext moves the first key of the right sibling into the child at
+// index idx.
+//
+// For leaves the pair moves across and the sibling's new first key becomes
+// the separator. For internal nodes the separator moves down to the child,
+// the sibling's first key moves up to replace it, and the sibling's first
+// child moves across with it.
+//
+// Parameters:
+//   - node: The parent node
+//   - idx: The index of the child receiving the key
+//
+// Time complexity: O(t)
+// Space complexity: O(1)
+func (bt *BPlusTree[K, V]) borrowFromNext(node *BPlusTreeNode[K, V], idx int) {
+	child, sibling := node.childs[idx], node.childs[idx+1]
+
+	if child.leaf {
+		child.keys = append(child.keys, sibling.keys[0])
+		child.values = append(child.values, sibling.values[0])
+		sibling.keys = slices.Delete(sibling.keys, 0, 1)
+		sibling.values = slices.Delete(sibling.values, 0, 1)
+		node.keys[idx] = sibling.keys[0]
+		return
+	}
+
+	child.keys = append(child.keys, node.keys[idx])
+	child.childs = append(child.childs, sibling.childs[0])
+	node.keys[idx] = sibling.keys[0]
+	sibling.keys = slices.Delete(sibling.keys, 0, 1)
+	sibling.childs = slices.Delete(sibling.childs, 0, 1)
+}
+
+// merge merges the child at index idx+1 into the child at index idx, and
+// removes the separator between them from node.
+//
+// Leaves simply concatenate their pairs and the right leaf is unlinked from
+// the leaf chain; the separator is dropped. Internal nodes pull the
+// separator down between their keys, as in a B-Tree.
+//
+// Parameters:
+//   - node: The parent node
+//   - idx: The index of the left child to merge into
+//
+// Time complexity: O(t)
+// Space complexity: O(1)
+func (bt *BPlusTree[K, V]) merge(node *BPlusTreeNode[K, V], idx int) {
+	child, sibling := node.childs[idx], node.childs[idx+1]
+
+	if child.leaf {
+		child.keys = append(child.keys, sibling.keys...)
+		child.values = append(child.values, sibling.values...)
+		child.next = sibling.next
+		if sibling.next != nil {
+			sibling.next.prev = child
+		}
+	} else {
+		child.keys = append(child.keys, node.keys[idx])
+		child.keys = append(child.keys, sibling.keys...)
+		child.childs = append(child.childs, sibling.childs...)
+	}
+
+	node.keys = slices.Delete(node.keys, idx, idx+1)
+	node.childs = slices.Delete(node.childs, idx+1, idx+2)
+}
@@ -0,0 +1,200 @@
+package bplustree
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// checkTree fails unless bt satisfies the B+ Tree properties: sorted keys,
+// t-1 to 2t-1 keys per non-root node, k+1 children and no values per
+// internal node, separators that divide their children's keys, all leaves
+// at the same depth and a leaf chain that visits every leaf in order in both
+// directions. It returns the number of keys.
+func checkTree[K, V any](t *testing.T, bt *BPlusTree[K, V]) int {
+	t.Helper()
+	if bt.root == nil {
+		if bt.size != 0 {
+			t.Fatalf("empty tree reports %d keys", bt.size)
+		}
+		return 0
+	}
+	leafDepth := -1
+	var leaves []*BPlusTreeNode[K, V]
+	var walk func(node *BPlusTreeNode[K, V], depth int, lo, hi *K) int
+	walk = func(node *BPlusTreeNode[K, V], depth int, lo, hi *K) int {
+		if len(node.keys) > 2*bt.t-1 || (node != bt.root && len(node.keys) < bt.t-1) || len(node.keys) == 0 {
+			t.Fatalf("node has %d keys with minimum degree %d", len(node.keys), bt.t)
+		}
+		for i, k := range node.keys {
+			// Keys equal to the lower separator belong to this subtree.
+			if (i > 0 && bt.compare(node.keys[i-1], k) >= 0) || (lo != nil && bt.compare(*lo, k) > 0) || (hi != nil && bt.compare(k, *hi) >= 0) {
+				t.Fatal("keys are out of order")
+			}
+		}
+		if node.leaf {
+			if len(node.childs) != 0 || len(node.values) != len(node.keys) {
+				t.Fatalf("leaf has %d keys, %d values and %d children", len(node.keys), len(node.values), len(node.childs))
+			}
+			if leafDepth == -1 {
+				leafDepth = depth
+			} else if depth != leafDepth {
+				t.Fatalf("leaves at depths %d and %d", leafDepth, depth)
+			}
+			leaves = append(leaves, node)
+			return len(node.keys)
+		}
+		if len(node.childs) != len(node.keys)+1 || len(node.values) != 0 || node.prev != nil || node.next != nil {
+			t.Fatalf("internal node has %d keys, %d children, %d values or leaf links", len(node.keys), len(node.childs), len(node.values))
+		}
+		count := 0
+		for i, child := range node.childs {
+			childLo, childHi := lo, hi
+			if i > 0 {
+				childLo = &node.keys[i-1]
+			}
+			if i < len(node.keys) {
+				childHi = &node.keys[i]
+			}
+			count += walk(child, depth+1, childLo, childHi)
+		}
+		return count
+	}
+	count := walk(bt.root, 0, nil, nil)
+
+	for i, leaf := range leaves {
+		var prev, next *BPlusTreeNode[K, V]
+		if i > 0 {
+			prev = leaves[i-1]
+		}
+		if i < len(leaves)-1 {
+			next = leaves[i+1]
+		}
+		if leaf.prev != prev || leaf.next != next {
+			t.Fatalf("leaf %d of %d is linked out of order", i, len(leaves))
+		}
+	}
+	if count != bt.size {
+		t.Fatalf("tree holds %d keys but reports %d", count, bt.size)
+	}
+	return count
+}
+
+func TestPutGetDelete(t *testing.T) {
+	bt := NewBPlusTree[int, string](2)
+	if _, replaced := bt.Put(10, "ten"); replaced {
+		t.Fatal("expected a new key not to replace anything")
+	}
+	bt.Put(20, "twenty")
+	bt.Put(5, "five")
+	if old, replaced := bt.Put(10, "TEN"); !replaced || old != "ten" {
+		t.Fatalf("expected to replace ten, got %q, %v", old, replaced)
+	}
+	if v, ok := bt.Get(10); !ok || v != "TEN" {
+		t.Fatalf("expected TEN, got %q, %v", v, ok)
+	}
+	if _, ok := bt.Get(15); ok {
+		t.Fatal("expected 15 to be absent")
+	}
+	if v, ok := bt.Delete(5); !ok || v != "five" {
+		t.Fatalf("expected to delete five, got %q, %v", v, ok)
+	}
+	if _, ok := bt.Delete(5); ok {
+		t.Fatal("expected a second delete to fail")
+	}
+	if n := checkTree(t, bt); n != 2 {
+		t.Fatalf("expected 2 keys, got %d", n)
+	}
+}
+
+func TestSeparatorsOutliveDeletedKeys(t *testing.T) {
+	bt := NewBPlusTree[int, int](2)
+	for i := 0; i < 4; i++ {
+		bt.Put(i, i)
+	}
+	// Leaves [0 1] [2 3] under separator 2.
+	if bt.Height() != 2 || bt.root.keys[0] != 2 {
+		t.Fatalf("expected two leaves split at 2, got height %d", bt.Height())
+	}
+	bt.Delete(2)
+	if bt.Search(2) || !bt.Search(3) || bt.root.keys[0] != 2 {
+		t.Fatal("expected 2 to be gone from the leaf but kept as a separator")
+	}
+	bt.Put(2, 20)
+	if v, ok := bt.Get(2); !ok || v != 20 || checkTree(t, bt) != 4 {
+		t.Fatalf("expected 2 to be stored again, got %d, %v", v, ok)
+	}
+}
+
+func TestMatchesMap(t *testing.T) {
+	for _, degree := range []int{2, 3, 5} {
+		bt := NewBPlusTree[int, int](degree)
+		want := make(map[int]int)
+		r := rand.New(rand.NewSource(int64(degree)))
+		for i := 0; i < 5000; i++ {
+			k := r.Intn(500)
+			switch r.Intn(3) {
+			case 0, 1:
+				old, replaced := bt.Put(k, i)
+				prev, existed := want[k]
+				if replaced != existed || old != prev {
+					t.Fatalf("Put(%d): got %d, %v; want %d, %v", k, old, replaced, prev, existed)
+				}
+				want[k] = i
+			case 2:
+				v, ok := bt.Delete(k)
+				prev, existed := want[k]
+				if ok != existed || v != prev {
+					t.Fatalf("Delete(%d): got %d, %v; want %d, %v", k, v, ok, prev, existed)
+				}
+				delete(want, k)
+			}
+			if i%250 == 0 {
+				if n := checkTree(t, bt); n != len(want) {
+					t.Fatalf("expected %d keys, got %d", len(want), n)
+				}
+			}
+		}
+		for k := 0; k < 500; k++ {
+			v, ok := bt.Get(k)
+			prev, existed := want[k]
+			if ok != existed || v != prev || bt.Search(k) != existed {
+				t.Fatalf("Get(%d): got %d, %v; want %d, %v", k, v, ok, prev, existed)
+			}
+		}
+		for k := range want {
+			bt.Remove(k)
+			if bt.Len()%50 == 0 {
+				checkTree(t, bt)
+			}
+		}
+		if bt.root != nil || bt.Len() != 0 {
+			t.Fatalf("degree %d: expected an empty tree after removing every key", degree)
+		}
+	}
+}
+
+func TestCustomComparator(t *testing.T) {
+	bt := NewBPlusTreeFunc[string, int](2, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	for i, k := range []string{"banana", "Apple", "cherry", "APPLE", "date"} {
+		bt.Put(k, i)
+	}
+	if v, ok := bt.Get("apple"); !ok || v != 3 {
+		t.Fatalf("expected apple to hold 3, got %d, %v", v, ok)
+	}
+	if n := checkTree(t, bt); n != 4 {
+		t.Fatalf("expected 4 keys, got %d", n)
+	}
+}
+
+func TestMinimumDegreeIsRaised(t *testing.T) {
+	bt := NewBPlusTree[int, struct{}](0)
+	for i := 0; i < 100; i++ {
+		bt.Insert(i, struct{}{})
+	}
+	if bt.t != 2 || checkTree(t, bt) != 100 {
+		t.Fatal("expected a degree below 2 to behave as 2")
+	}
+}
@@ -0,0 +1,210 @@
+package bplustree
+
+import "iter"
+
+// boundKind says whether a Bound includes its key, excludes it, or is open.
+type boundKind int
+
+const (
+	unbounded boundKind = iota
+	inclusive
+	exclusive
+)
+
+// Bound is one end of a key range passed to Range. The zero Bound is
+// unbounded.
+type Bound[K any] struct {
+	key  K
+	kind boundKind
+}
+
+// Inclusive returns a bound that includes key.
+func Inclusive[K any](key K) Bound[K] {
+	return Bound[K]{key: key, kind: inclusive}
+}
+
+// Exclusive returns a bound that excludes key.
+func Exclusive[K any](key K) Bound[K] {
+	return Bound[K]{key: key, kind: exclusive}
+}
+
+// Unbounded returns a bound that leaves its end of the range open.
+func Unbounded[K any]() Bound[K] {
+	return Bound[K]{}
+}
+
+// Len returns the number of keys stored in the B+ Tree.
+//
+// Time complexity: O(1)
+// Space complexity: O(1)
+func (bt *BPlusTree[K, V]) Len() int {
+	return bt.size
+}
+
+// Height returns the number of levels in the B+ Tree: 0 when it is empty
+// and 1 when the root is a leaf.
+//
+// Time complexity: O(log_t(n))
+// Space complexity: O(1)
+func (bt *BPlusTree[K, V]) Height() int {
+	height := 0
+	for node := bt.root; node != nil; height++ {
+		if node.leaf {
+			node = nil
+		} else {
+			node = node.childs[0]
+		}
+	}
+	return height
+}
+
+// All returns an iterator over the key-value pairs of the B+ Tree in
+// ascending key order. Ranging stops early when the loop body breaks.
+//
+// The tree must not be modified while ranging.
+//
+// Time complexity: O(log_t(n) + n) for a full traversal
+// Space complexity: O(1)
+//
+// Example:
+//
+//	for k, v := range bt.All() {
+//		fmt.Println(k, v)
+//	}
+func (bt *BPlusTree[K, V]) All() iter.Seq2[K, V] {
+	return bt.Range(Unbounded[K](), Unbounded[K]())
+}
+
+// Backward returns an iterator over the key-value pairs of the B+ Tree in
+// descending key order, walking the leaf chain from the last leaf.
+// Ranging stops early when the loop body breaks.
+//
+// Time complexity: O(log_t(n) + n) for a full traversal
+// Space complexity: O(1)
+func (bt *BPlusTree[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for leaf := bt.lastLeaf(); leaf != nil; leaf = leaf.prev {
+			for i := len(leaf.keys) - 1; i >= 0; i-- {
+				if !yield(leaf.keys[i], leaf.values[i]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the key-value pairs with keys between lo
+// and hi, in ascending key order. Each bound is built with Inclusive,
+// Exclusive or Unbounded; a range whose lo is above its hi is empty.
+//
+// Range descends once to the leaf holding lo, then follows the leaf chain
+// until it passes hi, so a scan touches no internal node after the first
+// descent.
+//
+// Parameters:
+//   - lo: The lower bound of the range
+//   - hi: The upper bound of the range
+//
+// Returns:
+//   - iter.Seq2[K, V]: An iterator over the pairs in the range
+//
+// Time complexity: O(log t * log_t(n) + m) where m is the number of pairs yielded
+// Space complexity: O(1)
+//
+// Example:
+//
+//	// Keys k with 10 <= k < 20
+//	for k, v := range bt.Range(bplustree.Inclusive(10), bplustree.Exclusive(20)) {
+//		fmt.Println(k, v)
+//	}
+func (bt *BPlusTree[K, V]) Range(lo, hi Bound[K]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		leaf, i := bt.firstLeaf(), 0
+		if lo.kind != unbounded {
+			leaf = bt.findLeaf(lo.key)
+			if leaf != nil {
+				var found bool
+				i, found = bt.find(leaf, lo.key)
+				if found && lo.kind == exclusive {
+					i++
+				}
+			}
+		}
+
+		for ; leaf != nil; leaf, i = leaf.next, 0 {
+			for ; i < len(leaf.keys); i++ {
+				if !bt.belowHigh(leaf.keys[i], hi) || !yield(leaf.keys[i], leaf.values[i]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// belowHigh reports whether key lies on the low side of hi.
+func (bt *BPlusTree[K, V]) belowHigh(key K, hi Bound[K]) bool {
+	switch hi.kind {
+	case inclusive:
+		return bt.compare(key, hi.key) <= 0
+	case exclusive:
+		return bt.compare(key, hi.key) < 0
+	}
+	return true
+}
+
+// firstLeaf returns the leftmost leaf, or nil if the tree is empty.
+func (bt *BPlusTree[K, V]) firstLeaf() *BPlusTreeNode[K, V] {
+	node := bt.root
+	for node != nil && !node.leaf {
+		node = node.childs[0]
+	}
+	return node
+}
+
+// lastLeaf returns the rightmost leaf, or nil if the tree is empty.
+func (bt *BPlusTree[K, V]) lastLeaf() *BPlusTreeNode[K, V] {
+	node := bt.root
+	for node != nil && !node.leaf {
+		node = node.childs[len(node.childs)-1]
+	}
+	return node
+}
+
+// Min returns the smallest key in the B+ Tree and its value.
+//
+// Returns:
+//   - K: The smallest key
+//   - V: Its value
+//   - bool: false if the tree is empty
+//
+// Time complexity: O(log_t(n))
+// Space complexity: O(1)
+func (bt *BPlusTree[K, V]) Min() (K, V, bool) {
+	leaf := bt.firstLeaf()
+	if leaf == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return leaf.keys[0], leaf.values[0], true
+}
+
+// Max returns the largest key in the B+ Tree and its value.
+//
+// Returns:
+//   - K: The largest key
+//   - V: Its value
+//   - bool: false if the tree is empty
+//
+// Time complexity: O(log_t(n))
+// Space complexity: O(1)
+func (bt *BPlusTree[K, V]) Max() (K, V, bool) {
+	leaf := bt.lastLeaf()
+	if leaf == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	last := len(leaf.keys) - 1
+	return leaf.keys[last], leaf.values[last], true
+}
@@ -0,0 +1,227 @@
+package bplustree
+
+import (
+	"iter"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/JeanGrijp/go-datastructures/pkg/btree"
+)
+
+// randomTree fills a tree of the given degree with n distinct random keys
+// below 4n, each mapped to twice its value, and returns the sorted keys.
+func randomTree(degree, n int, seed int64) (*BPlusTree[int, int], []int) {
+	bt := NewBPlusTree[int, int](degree)
+	keys := rand.New(rand.NewSource(seed)).Perm(4 * n)[:n]
+	for _, k := range keys {
+		bt.Put(k, 2*k)
+	}
+	slices.Sort(keys)
+	return bt, keys
+}
+
+// collect returns the keys seq yields, checking that each value is twice
+// its key as randomTree stores them.
+func collect(t *testing.T, seq iter.Seq2[int, int]) []int {
+	t.Helper()
+	var keys []int
+	for k, v := range seq {
+		if v != 2*k {
+			t.Fatalf("key %d has value %d", k, v)
+		}
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestAllAndBackward(t *testing.T) {
+	bt, keys := randomTree(3, 300, 1)
+	if got := collect(t, bt.All()); !reflect.DeepEqual(got, keys) {
+		t.Fatalf("All: expected %d sorted keys, got %v", len(keys), got)
+	}
+	backward := slices.Clone(keys)
+	slices.Reverse(backward)
+	if got := collect(t, bt.Backward()); !reflect.DeepEqual(got, backward) {
+		t.Fatalf("Backward: expected %d keys in reverse order, got %v", len(keys), got)
+	}
+
+	// Breaking out of the loop stops the traversal.
+	var first []int
+	for k := range bt.All() {
+		if len(first) == 5 {
+			break
+		}
+		first = append(first, k)
+	}
+	if !reflect.DeepEqual(first, keys[:5]) {
+		t.Fatalf("expected %v, got %v", keys[:5], first)
+	}
+
+	empty := NewBPlusTree[int, int](2)
+	if len(collect(t, empty.All())) != 0 || len(collect(t, empty.Backward())) != 0 {
+		t.Fatal("expected an empty tree to yield nothing")
+	}
+}
+
+func TestRange(t *testing.T) {
+	bt, keys := randomTree(2, 200, 2)
+	// Delete every third key so that some separators no longer match a
+	// stored key and bounds land on them.
+	for i := 0; i < len(keys); i += 3 {
+		bt.Delete(keys[i])
+	}
+	keys = slices.DeleteFunc(keys, func(k int) bool { return !bt.Search(k) })
+
+	r := rand.New(rand.NewSource(3))
+	bound := func() (Bound[int], func(k int, low bool) bool) {
+		key := r.Intn(850) - 25
+		switch r.Intn(3) {
+		case 0:
+			return Inclusive(key), func(k int, low bool) bool {
+				return (low && k >= key) || (!low && k <= key)
+			}
+		case 1:
+			return Exclusive(key), func(k int, low bool) bool {
+				return (low && k > key) || (!low && k < key)
+			}
+		}
+		return Unbounded[int](), func(int, bool) bool { return true }
+	}
+
+	for i := 0; i < 500; i++ {
+		lo, inLo := bound()
+		hi, inHi := bound()
+		var want []int
+		for _, k := range keys {
+			if inLo(k, true) && inHi(k, false) {
+				want = append(want, k)
+			}
+		}
+		if got := collect(t, bt.Range(lo, hi)); !reflect.DeepEqual(got, want) {
+			t.Fatalf("Range(%v, %v): expected %v, got %v", lo, hi, want, got)
+		}
+	}
+
+	// Bounds on keys that are present.
+	k0, k1 := keys[10], keys[20]
+	if got := collect(t, bt.Range(Inclusive(k0), Inclusive(k1))); !reflect.DeepEqual(got, keys[10:21]) {
+		t.Fatalf("expected %v, got %v", keys[10:21], got)
+	}
+	if got := collect(t, bt.Range(Exclusive(k0), Exclusive(k1))); !reflect.DeepEqual(got, keys[11:20]) {
+		t.Fatalf("expected %v, got %v", keys[11:20], got)
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	bt, keys := randomTree(4, 500, 4)
+	if k, v, ok := bt.Min(); !ok || k != keys[0] || v != 2*k {
+		t.Fatalf("Min: expected %d, got %d, %v", keys[0], k, ok)
+	}
+	if k, _, ok := bt.Max(); !ok || k != keys[len(keys)-1] {
+		t.Fatalf("Max: expected %d, got %d, %v", keys[len(keys)-1], k, ok)
+	}
+
+	empty := NewBPlusTree[int, int](2)
+	if _, _, ok := empty.Min(); ok {
+		t.Fatal("expected Min of an empty tree to fail")
+	}
+	if _, _, ok := empty.Max(); ok {
+		t.Fatal("expected Max of an empty tree to fail")
+	}
+}
+
+func TestLenAndHeight(t *testing.T) {
+	bt := NewBPlusTree[int, int](2)
+	if bt.Len() != 0 || bt.Height() != 0 {
+		t.Fatal("expected an empty tree to have no keys and no levels")
+	}
+	bt.Put(1, 1)
+	if bt.Len() != 1 || bt.Height() != 1 {
+		t.Fatalf("expected one key on one level, got %d on %d", bt.Len(), bt.Height())
+	}
+	for i := 0; i < 1000; i++ {
+		bt.Put(i%700, i)
+	}
+	if bt.Len() != 700 {
+		t.Fatalf("expected replaced keys not to count twice, got %d", bt.Len())
+	}
+	if h := bt.Height(); h < 5 || h > 10 {
+		t.Fatalf("expected a height between 5 and 10, got %d", h)
+	}
+	for i := 0; i < 700; i += 2 {
+		bt.Remove(i)
+	}
+	bt.Remove(-1)
+	if bt.Len() != 350 || checkTree(t, bt) != 350 {
+		t.Fatalf("expected 350 keys after removals, got %d", bt.Len())
+	}
+}
+
+// The benchmarks below run the same workloads against btree.BTree so the
+// two trees can be compared with go test -bench.
+
+const benchKeys = 100_000
+
+func BenchmarkPut(b *testing.B) {
+	keys := rand.New(rand.NewSource(1)).Perm(benchKeys)
+	b.Run("bplustree", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			bt := NewBPlusTree[int, int](32)
+			for _, k := range keys {
+				bt.Put(k, k)
+			}
+		}
+	})
+	b.Run("btree", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			bt := btree.NewBTree[int, int](32)
+			for _, k := range keys {
+				bt.Put(k, k)
+			}
+		}
+	})
+}
+
+func BenchmarkGet(b *testing.B) {
+	keys := rand.New(rand.NewSource(1)).Perm(benchKeys)
+	plus, classic := NewBPlusTree[int, int](32), btree.NewBTree[int, int](32)
+	for _, k := range keys {
+		plus.Put(k, k)
+		classic.Put(k, k)
+	}
+	b.Run("bplustree", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			plus.Get(keys[i%benchKeys])
+		}
+	})
+	b.Run("btree", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			classic.Get(keys[i%benchKeys])
+		}
+	})
+}
+
+func BenchmarkRange(b *testing.B) {
+	plus, classic := NewBPlusTree[int, int](32), btree.NewBTree[int, int](32)
+	for _, k := range rand.New(rand.NewSource(1)).Perm(benchKeys) {
+		plus.Put(k, k)
+		classic.Put(k, k)
+	}
+	// Scan 1000 keys from a different starting point each time.
+	b.Run("bplustree", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			lo := i * 7919 % (benchKeys - 1000)
+			for range plus.Range(Inclusive(lo), Exclusive(lo+1000)) {
+			}
+		}
+	})
+	b.Run("btree", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			lo := i * 7919 % (benchKeys - 1000)
+			for range classic.Range(btree.Inclusive(lo), btree.Exclusive(lo+1000)) {
+			}
+		}
+	})
+}
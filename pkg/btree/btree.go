@@ -8,6 +8,10 @@
 //   - All leaves appear at the same level
 //   - A non-leaf node with k keys has k+1 children
 //
+// The tree maps keys of any type K to values of any type V. Keys are ordered
+// by a comparator, which defaults to cmp.Compare for ordered types, and each
+// key is stored at most once.
+//
 // B-Trees are commonly used in databases and file systems where large blocks
 // of data are read and written. They minimize disk I/O operations by keeping
 // the tree height low.
@@ -16,47 +20,83 @@
 // Space complexity: O(n)
 package btree
 
+import (
+	"cmp"
+	"slices"
+)
+
 // BTreeNode represents a single node in the B-Tree.
-// Each node contains keys in sorted order and pointers to child nodes.
-type BTreeNode struct {
-	leaf   bool         // Indicates whether this node is a leaf (has no children)
-	keys   []int        // Slice of keys stored in this node, always kept in sorted order
-	childs []*BTreeNode // Slice of pointers to child nodes (empty if leaf is true)
+// Each node contains keys in sorted order, their values, and pointers to
+// child nodes.
+type BTreeNode[K, V any] struct {
+	leaf   bool               // Indicates whether this node is a leaf (has no children)
+	keys   []K                // Slice of keys stored in this node, always kept in sorted order
+	values []V                // values[i] is the value stored under keys[i]
+	childs []*BTreeNode[K, V] // Slice of pointers to child nodes (empty if leaf is true)
 }
 
 // BTree represents a B-Tree data structure with a specified minimum degree.
 // The minimum degree t determines the range of keys each node can hold.
-type BTree struct {
-	root *BTreeNode // Pointer to the root node of the tree (nil if tree is empty)
-	t    int        // Minimum degree: each node can have [t-1, 2t-1] keys (except root)
+type BTree[K, V any] struct {
+	root    *BTreeNode[K, V] // Pointer to the root node of the tree (nil if tree is empty)
+	t       int              // Minimum degree: each node can have [t-1, 2t-1] keys (except root)
+	size    int              // Number of keys stored in the tree
+	version int              // Incremented whenever nodes may have been restructured, so cursors can re-seek
+	compare func(a, b K) int // Orders keys: negative if a < b, zero if equal, positive if a > b
 }
 
-// NewBTree creates and returns a new empty B-Tree with the specified minimum degree t.
+// NewBTree creates and returns a new empty B-Tree with the specified minimum
+// degree t, ordering keys with cmp.Compare.
 // The minimum degree determines the capacity of each node:
 //   - Each node can hold between t-1 and 2t-1 keys
 //   - Each non-leaf node can have between t and 2t children
 //
 // Parameters:
-//   - t: The minimum degree of the B-Tree (must be >= 2 for a valid B-Tree)
+//   - t: The minimum degree of the B-Tree (values below 2 are raised to 2)
+//
+// Returns:
+//   - *BTree[K, V]: A pointer to the newly created empty B-Tree
+//
+// Time complexity: O(1)
+// Space complexity: O(1)
+//
+// Example:
+//
+//	bt := btree.NewBTree[int, string](3)  // Creates a B-Tree with minimum degree 3
+//	bt.Put(10, "ten")
+//	bt.Put(20, "twenty")
+//	bt.Put(5, "five")
+func NewBTree[K cmp.Ordered, V any](t int) *BTree[K, V] {
+	return NewBTreeFunc[K, V](t, cmp.Compare[K])
+}
+
+// NewBTreeFunc creates and returns a new empty B-Tree with the specified
+// minimum degree t, ordering keys with compare. Like cmp.Compare, compare
+// must return a negative number if a < b, zero if a == b and a positive
+// number if a > b, and it must define a strict weak ordering.
+//
+// Parameters:
+//   - t: The minimum degree of the B-Tree (values below 2 are raised to 2)
+//   - compare: The key comparator; nil panics on first use
 //
 // Returns:
-//   - *BTree: A pointer to the newly created empty B-Tree
+//   - *BTree[K, V]: A pointer to the newly created empty B-Tree
 //
 // Time complexity: O(1)
 // Space complexity: O(1)
 //
 // Example:
 //
-//	bt := btree.NewBTree(3)  // Creates a B-Tree with minimum degree 3
-//	bt.Insert(10)
-//	bt.Insert(20)
-//	bt.Insert(5)
-func NewBTree(t int) *BTree {
-	return &BTree{t: t}
+//	// Case-insensitive string keys
+//	bt := btree.NewBTreeFunc[string, int](2, func(a, b string) int {
+//		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+//	})
+func NewBTreeFunc[K, V any](t int, compare func(a, b K) int) *BTree[K, V] {
+	return &BTree[K, V]{t: max(t, 2), compare: compare}
 }
 
-// Insert adds a new key to the B-Tree while maintaining all B-Tree properties.
-// If the key already exists, it will be inserted as a duplicate.
+// Put stores value under key while maintaining all B-Tree properties.
+// If the key already exists, its value is replaced.
 //
 // The insertion process:
 //  1. If the tree is empty, create a new root with the key
@@ -64,33 +104,64 @@ func NewBTree(t int) *BTree {
 //  3. Insert the key into the appropriate position using insertNonFull
 //
 // Parameters:
-//   - k: The integer key to insert into the tree
+//   - key: The key to store
+//   - value: The value to store under key
+//
+// Returns:
+//   - V: The value previously stored under key, or the zero value
+//   - bool: true if key was already present and its value was replaced
 //
 // Time complexity: O(t * log_t(n)) where t is the minimum degree and n is the number of keys
 // Space complexity: O(log_t(n)) for the recursion stack
 //
 // Example:
 //
-//	bt := btree.NewBTree(2)
-//	bt.Insert(10)
-//	bt.Insert(20)
-//	bt.Insert(5)
-//	bt.Insert(15)
-//	// Tree now contains: 5, 10, 15, 20
-func (bt *BTree) Insert(k int) {
+//	bt := btree.NewBTree[int, string](2)
+//	bt.Put(10, "ten")
+//	old, replaced := bt.Put(10, "TEN")  // returns "ten", true
+func (bt *BTree[K, V]) Put(key K, value V) (V, bool) {
+	bt.version++
 	if bt.root == nil {
-		bt.root = &BTreeNode{leaf: true, keys: []int{k}}
-		return
+		bt.root = &BTreeNode[K, V]{leaf: true, keys: []K{key}, values: []V{value}}
+		bt.size = 1
+		var zero V
+		return zero, false
 	}
 
 	// If the root is full, the tree grows in height
 	if len(bt.root.keys) == 2*bt.t-1 {
 		oldRoot := bt.root
-		bt.root = &BTreeNode{leaf: false}
+		bt.root = &BTreeNode[K, V]{leaf: false}
 		bt.root.childs = append(bt.root.childs, oldRoot)
 		bt.splitChild(bt.root, 0, oldRoot)
 	}
-	bt.insertNonFull(bt.root, k)
+	old, replaced := bt.insertNonFull(bt.root, key, value)
+	if !replaced {
+		bt.size++
+	}
+	return old, replaced
+}
+
+// Insert stores value under key, replacing the value of an existing key.
+// It is Put without the previous value.
+//
+// Parameters:
+//   - key: The key to store
+//   - value: The value to store under key
+//
+// Time complexity: O(t * log_t(n)) where t is the minimum degree and n is the number of keys
+// Space complexity: O(log_t(n)) for the recursion stack
+//
+// Example:
+//
+//	bt := btree.NewBTree[int, string](2)
+//	bt.Insert(10, "ten")
+//	bt.Insert(20, "twenty")
+//	bt.Insert(5, "five")
+//	bt.Insert(15, "fifteen")
+//	// Tree now contains: 5, 10, 15, 20
+func (bt *BTree[K, V]) Insert(key K, value V) {
+	bt.Put(key, value)
 }
 
 // splitChild splits a full child node into two nodes during insertion.
@@ -98,9 +169,9 @@ func (bt *BTree) Insert(k int) {
 //
 // The split process:
 //  1. Create a new node to hold the right half of the full node's keys
-//  2. Move the t-1 rightmost keys to the new node
+//  2. Move the t-1 rightmost keys and their values to the new node
 //  3. Move corresponding children if the node is not a leaf
-//  4. Promote the middle key to the parent node
+//  4. Promote the middle key and its value to the parent node
 //  5. Insert the new node as a child of the parent
 //
 // Parameters:
@@ -110,137 +181,182 @@ func (bt *BTree) Insert(k int) {
 //
 // Time complexity: O(t) where t is the minimum degree
 // Space complexity: O(t) for the new node
-func (bt *BTree) splitChild(parent *BTreeNode, i int, fullNode *BTreeNode) {
+func (bt *BTree[K, V]) splitChild(parent *BTreeNode[K, V], i int, fullNode *BTreeNode[K, V]) {
 	t := bt.t
-	newNode := &BTreeNode{leaf: fullNode.leaf}
+	newNode := &BTreeNode[K, V]{leaf: fullNode.leaf}
 
 	// The new node receives the t-1 rightmost keys from the full node
 	newNode.keys = append(newNode.keys, fullNode.keys[t:]...)
+	newNode.values = append(newNode.values, fullNode.values[t:]...)
 	if !fullNode.leaf {
 		newNode.childs = append(newNode.childs, fullNode.childs[t:]...)
 	}
 
 	// The middle key is promoted to the parent
-	middleKey := fullNode.keys[t-1]
+	middleKey, middleValue := fullNode.keys[t-1], fullNode.values[t-1]
+	clear(fullNode.keys[t-1:])
+	clear(fullNode.values[t-1:])
 	fullNode.keys = fullNode.keys[:t-1]
+	fullNode.values = fullNode.values[:t-1]
 	if !fullNode.leaf {
+		clear(fullNode.childs[t:])
 		fullNode.childs = fullNode.childs[:t]
 	}
 
 	// Insert the new node and the middle key into the parent
-	parent.keys = append(parent.keys, 0)
-	copy(parent.keys[i+1:], parent.keys[i:])
-	parent.keys[i] = middleKey
-
-	parent.childs = append(parent.childs, nil)
-	copy(parent.childs[i+2:], parent.childs[i+1:])
-	parent.childs[i+1] = newNode
+	parent.keys = slices.Insert(parent.keys, i, middleKey)
+	parent.values = slices.Insert(parent.values, i, middleValue)
+	parent.childs = slices.Insert(parent.childs, i+1, newNode)
 }
 
 // insertNonFull inserts a key into a node that is guaranteed to be non-full.
-// This is a helper function called by Insert after ensuring the node has room.
+// This is a helper function called by Put after ensuring the node has room.
 //
 // The insertion process:
-//  1. If the node is a leaf, insert the key in sorted order
-//  2. If the node is internal, find the appropriate child to descend into
-//  3. If that child is full, split it first, then recurse
+//  1. If the key is in the node, replace its value
+//  2. If the node is a leaf, insert the key in sorted order
+//  3. If the node is internal, find the appropriate child to descend into
+//  4. If that child is full, split it first, then recurse
 //
 // Parameters:
 //   - node: The non-full node where the key should be inserted
-//   - k: The key to insert
+//   - key: The key to insert
+//   - value: The value to store under key
+//
+// Returns:
+//   - V: The replaced value, or the zero value
+//   - bool: true if the key was already present
 //
 // Time complexity: O(t * log_t(n))
 // Space complexity: O(log_t(n)) for recursion
-func (bt *BTree) insertNonFull(node *BTreeNode, k int) {
-	i := len(node.keys) - 1
+func (bt *BTree[K, V]) insertNonFull(node *BTreeNode[K, V], key K, value V) (V, bool) {
+	i, found := bt.find(node, key)
+	if found {
+		old := node.values[i]
+		node.values[i] = value
+		return old, true
+	}
 
 	if node.leaf {
 		// Insert the key at the correct position in the leaf
-		node.keys = append(node.keys, 0)
-		for i >= 0 && node.keys[i] > k {
-			node.keys[i+1] = node.keys[i]
-			i--
-		}
-		node.keys[i+1] = k
-	} else {
-		// Find the child where the key should be inserted
-		for i >= 0 && node.keys[i] > k {
-			i--
-		}
-		i++
-		if len(node.childs[i].keys) == 2*bt.t-1 {
-			bt.splitChild(node, i, node.childs[i])
-			if node.keys[i] < k {
-				i++
-			}
+		node.keys = slices.Insert(node.keys, i, key)
+		node.values = slices.Insert(node.values, i, value)
+		var zero V
+		return zero, false
+	}
+
+	// Descend into the child where the key belongs
+	if len(node.childs[i].keys) == 2*bt.t-1 {
+		bt.splitChild(node, i, node.childs[i])
+		switch c := bt.compare(key, node.keys[i]); {
+		case c == 0:
+			old := node.values[i]
+			node.values[i] = value
+			return old, true
+		case c > 0:
+			i++
 		}
-		bt.insertNonFull(node.childs[i], k)
 	}
+	return bt.insertNonFull(node.childs[i], key, value)
+}
+
+// find returns the index of the first key in node that is not less than key,
+// and whether that key equals key.
+//
+// Time complexity: O(log t) by binary search
+// Space complexity: O(1)
+func (bt *BTree[K, V]) find(node *BTreeNode[K, V], key K) (int, bool) {
+	return slices.BinarySearchFunc(node.keys, key, bt.compare)
 }
 
-// Search looks for a key in the subtree rooted at this node.
-// It traverses the tree from this node downward, comparing the key
+// search looks for a key in the subtree rooted at node.
+// It traverses the tree from node downward, comparing the key
 // with stored keys to determine the search path.
 //
 // The search process:
-//  1. Find the first key greater than or equal to k
-//  2. If the key is found, return true
-//  3. If this is a leaf node and key not found, return false
-//  4. Otherwise, recursively search in the appropriate child
+//  1. Find the first key greater than or equal to key
+//  2. If the key is found, return the node and index holding it
+//  3. If this is a leaf node and key not found, return nil
+//  4. Otherwise, continue in the appropriate child
 //
 // Parameters:
-//   - k: The key to search for
+//   - node: The root of the subtree to search
+//   - key: The key to search for
 //
 // Returns:
-//   - bool: true if the key exists in the subtree, false otherwise
+//   - *BTreeNode[K, V]: The node holding key, or nil if it is absent
+//   - int: The index of key in that node
 //
-// Time complexity: O(t * log_t(n)) where t is the minimum degree
-// Space complexity: O(log_t(n)) for recursion stack
-func (node *BTreeNode) search(k int) bool {
-	i := 0
-	for i < len(node.keys) && k > node.keys[i] {
-		i++
-	}
-
-	if i < len(node.keys) && node.keys[i] == k {
-		return true
+// Time complexity: O(log t * log_t(n)) where t is the minimum degree
+// Space complexity: O(1)
+func (bt *BTree[K, V]) search(node *BTreeNode[K, V], key K) (*BTreeNode[K, V], int) {
+	for node != nil {
+		i, found := bt.find(node, key)
+		if found {
+			return node, i
+		}
+		if node.leaf {
+			return nil, 0
+		}
+		node = node.childs[i]
 	}
+	return nil, 0
+}
 
-	if node.leaf {
-		return false
+// Get returns the value stored under key.
+//
+// Parameters:
+//   - key: The key to look up
+//
+// Returns:
+//   - V: The value stored under key, or the zero value
+//   - bool: true if the key exists in the tree, false otherwise
+//
+// Time complexity: O(log t * log_t(n)) where t is the minimum degree
+// Space complexity: O(1)
+//
+// Example:
+//
+//	bt := btree.NewBTree[int, string](2)
+//	bt.Put(10, "ten")
+//	v, ok := bt.Get(10)  // returns "ten", true
+//	v, ok = bt.Get(15)   // returns "", false
+func (bt *BTree[K, V]) Get(key K) (V, bool) {
+	node, i := bt.search(bt.root, key)
+	if node == nil {
+		var zero V
+		return zero, false
 	}
-
-	return node.childs[i].search(k)
+	return node.values[i], true
 }
 
 // Search looks for a key in the B-Tree.
 // Returns true if the key exists in the tree, false otherwise.
 //
 // Parameters:
-//   - k: The key to search for
+//   - key: The key to search for
 //
 // Returns:
 //   - bool: true if the key exists in the tree, false otherwise
 //
-// Time complexity: O(t * log_t(n)) where t is the minimum degree
-// Space complexity: O(log_t(n)) for recursion stack
+// Time complexity: O(log t * log_t(n)) where t is the minimum degree
+// Space complexity: O(1)
 //
 // Example:
 //
-//	bt := btree.NewBTree(2)
-//	bt.Insert(10)
-//	bt.Insert(20)
+//	bt := btree.NewBTree[int, string](2)
+//	bt.Insert(10, "ten")
+//	bt.Insert(20, "twenty")
 //	found := bt.Search(10)  // returns true
 //	found = bt.Search(15)   // returns false
-func (bt *BTree) Search(k int) bool {
-	if bt.root == nil {
-		return false
-	}
-	return bt.root.search(k)
+func (bt *BTree[K, V]) Search(key K) bool {
+	node, _ := bt.search(bt.root, key)
+	return node != nil
 }
 
-// Remove deletes a key from the B-Tree while maintaining all B-Tree properties.
-// If the key does not exist in the tree, the tree remains unchanged.
+// Delete removes a key from the B-Tree while maintaining all B-Tree properties,
+// and returns the value that was stored under it.
+// If the key does not exist in the tree, the tree's contents remain unchanged.
 //
 // The removal process handles several cases:
 //  1. Key is in a leaf node: simply remove it
@@ -248,25 +364,34 @@ func (bt *BTree) Search(k int) bool {
 //  3. After deletion, if root becomes empty, shrink the tree height
 //
 // Parameters:
-//   - k: The key to remove from the tree
+//   - key: The key to remove from the tree
+//
+// Returns:
+//   - V: The removed value, or the zero value
+//   - bool: true if the key was present
 //
 // Time complexity: O(t * log_t(n)) where t is the minimum degree
 // Space complexity: O(log_t(n)) for recursion stack
 //
 // Example:
 //
-//	bt := btree.NewBTree(2)
-//	bt.Insert(10)
-//	bt.Insert(20)
-//	bt.Insert(5)
-//	bt.Remove(10)  // Removes 10 from the tree
-//	// Tree now contains: 5, 20
-func (bt *BTree) Remove(k int) {
+//	bt := btree.NewBTree[int, string](2)
+//	bt.Put(10, "ten")
+//	bt.Put(20, "twenty")
+//	v, ok := bt.Delete(10)  // returns "ten", true
+//	// Tree now contains: 20
+func (bt *BTree[K, V]) Delete(key K) (V, bool) {
 	if bt.root == nil {
-		return
+		var zero V
+		return zero, false
 	}
 
-	bt.removeFromNode(bt.root, k)
+	// Even a missing key can trigger borrows and merges on the way down.
+	bt.version++
+	value, ok := bt.removeFromNode(bt.root, key)
+	if ok {
+		bt.size--
+	}
 
 	if len(bt.root.keys) == 0 {
 		if !bt.root.leaf {
@@ -275,6 +400,28 @@ func (bt *BTree) Remove(k int) {
 			bt.root = nil
 		}
 	}
+	return value, ok
+}
+
+// Remove deletes a key from the B-Tree. It is Delete without the removed
+// value; if the key does not exist in the tree, nothing happens.
+//
+// Parameters:
+//   - key: The key to remove from the tree
+//
+// Time complexity: O(t * log_t(n)) where t is the minimum degree
+// Space complexity: O(log_t(n)) for recursion stack
+//
+// Example:
+//
+//	bt := btree.NewBTree[int, string](2)
+//	bt.Insert(10, "ten")
+//	bt.Insert(20, "twenty")
+//	bt.Insert(5, "five")
+//	bt.Remove(10)  // Removes 10 from the tree
+//	// Tree now contains: 5, 20
+func (bt *BTree[K, V]) Remove(key K) {
+	bt.Delete(key)
 }
 
 // removeFromNode removes a key from the subtree rooted at the given node.
@@ -287,38 +434,41 @@ func (bt *BTree) Remove(k int) {
 //
 // Parameters:
 //   - node: The root of the subtree to remove from
-//   - k: The key to remove
+//   - key: The key to remove
+//
+// Returns:
+//   - V: The removed value, or the zero value
+//   - bool: true if the key was found
 //
 // Time complexity: O(t * log_t(n))
 // Space complexity: O(log_t(n)) for recursion
-func (bt *BTree) removeFromNode(node *BTreeNode, k int) {
-	idx := 0
-	for idx < len(node.keys) && node.keys[idx] < k {
-		idx++
-	}
+func (bt *BTree[K, V]) removeFromNode(node *BTreeNode[K, V], key K) (V, bool) {
+	idx, found := bt.find(node, key)
 
-	if idx < len(node.keys) && node.keys[idx] == k {
-		if node.leaf {
-			node.keys = append(node.keys[:idx], node.keys[idx+1:]...)
-		} else {
-			bt.removeFromNonLeaf(node, idx)
-		}
-	} else {
+	if found {
 		if node.leaf {
-			return
+			value := node.values[idx]
+			node.keys = slices.Delete(node.keys, idx, idx+1)
+			node.values = slices.Delete(node.values, idx, idx+1)
+			return value, true
 		}
+		return bt.removeFromNonLeaf(node, idx)
+	}
 
-		lastChild := idx == len(node.keys)
-		if len(node.childs[idx].keys) < bt.t {
-			bt.fill(node, idx)
-		}
+	if node.leaf {
+		var zero V
+		return zero, false
+	}
 
-		if lastChild && idx > len(node.keys) {
-			bt.removeFromNode(node.childs[idx-1], k)
-		} else {
-			bt.removeFromNode(node.childs[idx], k)
-		}
+	lastChild := idx == len(node.keys)
+	if len(node.childs[idx].keys) < bt.t {
+		bt.fill(node, idx)
 	}
+
+	if lastChild && idx > len(node.keys) {
+		return bt.removeFromNode(node.childs[idx-1], key)
+	}
+	return bt.removeFromNode(node.childs[idx], key)
 }
 
 // removeFromNonLeaf removes a key from an internal (non-leaf) node.
@@ -333,23 +483,28 @@ func (bt *BTree) removeFromNode(node *BTreeNode, k int) {
 //   - node: The internal node containing the key to remove
 //   - idx: The index of the key to remove in node.keys
 //
+// Returns:
+//   - V: The removed value
+//   - bool: Always true
+//
 // Time complexity: O(t * log_t(n))
 // Space complexity: O(log_t(n)) for recursion
-func (bt *BTree) removeFromNonLeaf(node *BTreeNode, idx int) {
-	k := node.keys[idx]
+func (bt *BTree[K, V]) removeFromNonLeaf(node *BTreeNode[K, V], idx int) (V, bool) {
+	k, value := node.keys[idx], node.values[idx]
 
 	if len(node.childs[idx].keys) >= bt.t {
-		pred := bt.getPredecessor(node, idx)
-		node.keys[idx] = pred
-		bt.removeFromNode(node.childs[idx], pred)
+		predKey, predValue := bt.getPredecessor(node, idx)
+		node.keys[idx], node.values[idx] = predKey, predValue
+		bt.removeFromNode(node.childs[idx], predKey)
 	} else if len(node.childs[idx+1].keys) >= bt.t {
-		succ := bt.getSuccessor(node, idx)
-		node.keys[idx] = succ
-		bt.removeFromNode(node.childs[idx+1], succ)
+		succKey, succValue := bt.getSuccessor(node, idx)
+		node.keys[idx], node.values[idx] = succKey, succValue
+		bt.removeFromNode(node.childs[idx+1], succKey)
 	} else {
 		bt.merge(node, idx)
 		bt.removeFromNode(node.childs[idx], k)
 	}
+	return value, true
 }
 
 // getPredecessor finds the predecessor of the key at index idx in node.
@@ -361,16 +516,18 @@ func (bt *BTree) removeFromNonLeaf(node *BTreeNode, idx int) {
 //   - idx: The index of the key in node.keys
 //
 // Returns:
-//   - int: The predecessor key (largest key smaller than node.keys[idx])
+//   - K: The predecessor key (largest key smaller than node.keys[idx])
+//   - V: The value stored under the predecessor
 //
 // Time complexity: O(log_t(n))
 // Space complexity: O(1)
-func (bt *BTree) getPredecessor(node *BTreeNode, idx int) int {
+func (bt *BTree[K, V]) getPredecessor(node *BTreeNode[K, V], idx int) (K, V) {
 	cur := node.childs[idx]
 	for !cur.leaf {
 		cur = cur.childs[len(cur.childs)-1]
 	}
-	return cur.keys[len(cur.keys)-1]
+	last := len(cur.keys) - 1
+	return cur.keys[last], cur.values[last]
 }
 
 // getSuccessor finds the successor of the key at index idx in node.
@@ -382,16 +539,17 @@ func (bt *BTree) getPredecessor(node *BTreeNode, idx int) int {
 //   - idx: The index of the key in node.keys
 //
 // Returns:
-//   - int: The successor key (smallest key larger than node.keys[idx])
+//   - K: The successor key (smallest key larger than node.keys[idx])
+//   - V: The value stored under the successor
 //
 // Time complexity: O(log_t(n))
 // Space complexity: O(1)
-func (bt *BTree) getSuccessor(node *BTreeNode, idx int) int {
+func (bt *BTree[K, V]) getSuccessor(node *BTreeNode[K, V], idx int) (K, V) {
 	cur := node.childs[idx+1]
 	for !cur.leaf {
 		cur = cur.childs[0]
 	}
-	return cur.keys[0]
+	return cur.keys[0], cur.values[0]
 }
 
 // fill ensures that the child at index idx has at least t keys.
@@ -409,7 +567,7 @@ func (bt *BTree) getSuccessor(node *BTreeNode, idx int) int {
 //
 // Time complexity: O(t)
 // Space complexity: O(1)
-func (bt *BTree) fill(node *BTreeNode, idx int) {
+func (bt *BTree[K, V]) fill(node *BTreeNode[K, V], idx int) {
 	if idx != 0 && len(node.childs[idx-1].keys) >= bt.t {
 		bt.borrowFromPrev(node, idx)
 	} else if idx != len(node.keys) && len(node.childs[idx+1].keys) >= bt.t {
@@ -431,24 +589,30 @@ func (bt *BTree) fill(node *BTreeNode, idx int) {
 //  2. Move the last key from sibling up to parent as new separator
 //  3. If not a leaf, move the last child pointer from sibling to child
 //
+// Values travel with their keys.
+//
 // Parameters:
 //   - node: The parent node containing the separator key
 //   - idx: The index of the child that needs a key
 //
 // Time complexity: O(t) due to slice prepend operations
-// Space complexity: O(t) for the new slices
-func (bt *BTree) borrowFromPrev(node *BTreeNode, idx int) {
+// Space complexity: O(1)
+func (bt *BTree[K, V]) borrowFromPrev(node *BTreeNode[K, V], idx int) {
 	child := node.childs[idx]
 	sibling := node.childs[idx-1]
 
-	child.keys = append([]int{node.keys[idx-1]}, child.keys...)
+	child.keys = slices.Insert(child.keys, 0, node.keys[idx-1])
+	child.values = slices.Insert(child.values, 0, node.values[idx-1])
 	if !child.leaf {
-		child.childs = append([]*BTreeNode{sibling.childs[len(sibling.childs)-1]}, child.childs...)
-		sibling.childs = sibling.childs[:len(sibling.childs)-1]
+		last := len(sibling.childs) - 1
+		child.childs = slices.Insert(child.childs, 0, sibling.childs[last])
+		sibling.childs = slices.Delete(sibling.childs, last, last+1)
 	}
 
-	node.keys[idx-1] = sibling.keys[len(sibling.keys)-1]
-	sibling.keys = sibling.keys[:len(sibling.keys)-1]
+	last := len(sibling.keys) - 1
+	node.keys[idx-1], node.values[idx-1] = sibling.keys[last], sibling.values[last]
+	sibling.keys = slices.Delete(sibling.keys, last, last+1)
+	sibling.values = slices.Delete(sibling.values, last, last+1)
 }
 
 // borrowFromNext borrows a key from the next (right) sibling.
@@ -459,32 +623,36 @@ func (bt *BTree) borrowFromPrev(node *BTreeNode, idx int) {
 //  2. Move the first key from sibling up to parent as new separator
 //  3. If not a leaf, move the first child pointer from sibling to child
 //
+// Values travel with their keys.
+//
 // Parameters:
 //   - node: The parent node containing the separator key
 //   - idx: The index of the child that needs a key
 //
 // Time complexity: O(t) due to slice operations
 // Space complexity: O(1)
-func (bt *BTree) borrowFromNext(node *BTreeNode, idx int) {
+func (bt *BTree[K, V]) borrowFromNext(node *BTreeNode[K, V], idx int) {
 	child := node.childs[idx]
 	sibling := node.childs[idx+1]
 
 	child.keys = append(child.keys, node.keys[idx])
+	child.values = append(child.values, node.values[idx])
 	if !child.leaf {
 		child.childs = append(child.childs, sibling.childs[0])
-		sibling.childs = sibling.childs[1:]
+		sibling.childs = slices.Delete(sibling.childs, 0, 1)
 	}
 
-	node.keys[idx] = sibling.keys[0]
-	sibling.keys = sibling.keys[1:]
+	node.keys[idx], node.values[idx] = sibling.keys[0], sibling.values[0]
+	sibling.keys = slices.Delete(sibling.keys, 0, 1)
+	sibling.values = slices.Delete(sibling.values, 0, 1)
 }
 
 // merge combines two sibling nodes into one, absorbing the separator key from parent.
 // This is used when both siblings have exactly t-1 keys and a key needs to be deleted.
 //
 // The merge process:
-//  1. Move the separator key from parent down to the left child
-//  2. Append all keys from right sibling to left child
+//  1. Move the separator key and its value from parent down to the left child
+//  2. Append all keys and values from right sibling to left child
 //  3. If not leaves, append all child pointers from right sibling
 //  4. Remove the separator key and right child pointer from parent
 //
@@ -496,17 +664,20 @@ func (bt *BTree) borrowFromNext(node *BTreeNode, idx int) {
 //
 // Time complexity: O(t)
 // Space complexity: O(1)
-func (bt *BTree) merge(node *BTreeNode, idx int) {
+func (bt *BTree[K, V]) merge(node *BTreeNode[K, V], idx int) {
 	child := node.childs[idx]
 	sibling := node.childs[idx+1]
 
 	child.keys = append(child.keys, node.keys[idx])
 	child.keys = append(child.keys, sibling.keys...)
+	child.values = append(child.values, node.values[idx])
+	child.values = append(child.values, sibling.values...)
 
 	if !child.leaf {
 		child.childs = append(child.childs, sibling.childs...)
 	}
 
-	node.keys = append(node.keys[:idx], node.keys[idx+1:]...)
-	node.childs = append(node.childs[:idx+1], node.childs[idx+2:]...)
+	node.keys = slices.Delete(node.keys, idx, idx+1)
+	node.values = slices.Delete(node.values, idx, idx+1)
+	node.childs = slices.Delete(node.childs, idx+1, idx+2)
 }
@@ -0,0 +1,197 @@
+package btree
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+)
+
+var (
+	// ErrUnsorted is returned by the bulk loaders when keys are not in
+	// strictly increasing order. Duplicate keys are unsorted too.
+	ErrUnsorted = errors.New("btree: keys are not in strictly increasing order")
+	// ErrFillFactor is returned by the bulk loaders when the fill factor is
+	// outside [0, 1].
+	ErrFillFactor = errors.New("btree: fill factor must be between 0 and 1")
+	// ErrLengthMismatch is returned by BulkLoadSlice when keys and values
+	// have different lengths.
+	ErrLengthMismatch = errors.New("btree: keys and values have different lengths")
+)
+
+// BulkLoad builds a B-Tree with minimum degree t from key-value pairs in
+// strictly increasing key order, ordering keys with cmp.Compare.
+//
+// Instead of inserting keys one by one, which splits nodes repeatedly and
+// leaves them about half full, it builds the tree bottom-up: it packs the
+// keys into leaves, promotes one separator key between each pair of leaves,
+// and packs the separators into the level above in the same way until a
+// single root remains. Every node but the root receives about fill × (2t-1)
+// keys, and never fewer than t-1, so the result is a valid B-Tree that
+// supports every operation, including later insertions and deletions.
+//
+// A fill factor of 1 packs nodes full, which suits read-only indexes; lower
+// values leave room for later insertions without immediate splits. Zero
+// means 1.
+//
+// Parameters:
+//   - t: The minimum degree of the B-Tree (values below 2 are raised to 2)
+//   - fill: The fraction of each node to fill, between 0 and 1
+//   - seq: The pairs to load, in strictly increasing key order
+//
+// Returns:
+//   - *BTree[K, V]: The loaded B-Tree
+//   - error: ErrFillFactor or ErrUnsorted (wrapped with the position of the
+//     offending key), or nil
+//
+// Time complexity: O(n)
+// Space complexity: O(n)
+//
+// Example:
+//
+//	// Index rows by position; slices.All yields increasing indices
+//	bt, err := btree.BulkLoad(50, 0.7, slices.All(rows))
+func BulkLoad[K cmp.Ordered, V any](t int, fill float64, seq iter.Seq2[K, V]) (*BTree[K, V], error) {
+	return BulkLoadFunc(t, fill, cmp.Compare[K], seq)
+}
+
+// BulkLoadFunc is BulkLoad with a custom key comparator, which follows the
+// contract of NewBTreeFunc.
+//
+// Parameters:
+//   - t: The minimum degree of the B-Tree (values below 2 are raised to 2)
+//   - fill: The fraction of each node to fill, between 0 and 1
+//   - compare: The key comparator
+//   - seq: The pairs to load, in strictly increasing order under compare
+//
+// Returns:
+//   - *BTree[K, V]: The loaded B-Tree
+//   - error: ErrFillFactor or ErrUnsorted, or nil
+//
+// Time complexity: O(n)
+// Space complexity: O(n)
+func BulkLoadFunc[K, V any](t int, fill float64, compare func(a, b K) int, seq iter.Seq2[K, V]) (*BTree[K, V], error) {
+	if !(fill >= 0 && fill <= 1) {
+		return nil, ErrFillFactor
+	}
+	var (
+		keys   []K
+		values []V
+	)
+	for k, v := range seq {
+		if n := len(keys); n > 0 && compare(keys[n-1], k) >= 0 {
+			return nil, fmt.Errorf("%w: key at position %d", ErrUnsorted, n)
+		}
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+
+	bt := NewBTreeFunc[K, V](t, compare)
+	bt.load(fill, keys, values)
+	return bt, nil
+}
+
+// BulkLoadSlice is BulkLoad over parallel slices: values[i] is stored under
+// keys[i]. The slices are copied, not retained.
+//
+// Parameters:
+//   - t: The minimum degree of the B-Tree (values below 2 are raised to 2)
+//   - fill: The fraction of each node to fill, between 0 and 1
+//   - keys: The keys to load, in strictly increasing order
+//   - values: The values to store under keys
+//
+// Returns:
+//   - *BTree[K, V]: The loaded B-Tree
+//   - error: ErrLengthMismatch, ErrFillFactor or ErrUnsorted, or nil
+//
+// Time complexity: O(n)
+// Space complexity: O(n)
+//
+// Example:
+//
+//	ids := []int{1001, 2045, 3089}
+//	rows := []string{"page 0", "page 0", "page 1"}
+//	bt, err := btree.BulkLoadSlice(100, 1, ids, rows)
+func BulkLoadSlice[K cmp.Ordered, V any](t int, fill float64, keys []K, values []V) (*BTree[K, V], error) {
+	if len(keys) != len(values) {
+		return nil, ErrLengthMismatch
+	}
+	return BulkLoad(t, fill, func(yield func(K, V) bool) {
+		for i, k := range keys {
+			if !yield(k, values[i]) {
+				return
+			}
+		}
+	})
+}
+
+// load replaces the contents of the tree with keys and values, which must
+// be sorted and free of duplicates, building it level by level.
+func (bt *BTree[K, V]) load(fill float64, keys []K, values []V) {
+	bt.root, bt.size = nil, len(keys)
+	bt.version++
+	if len(keys) == 0 {
+		return
+	}
+	if fill == 0 {
+		fill = 1
+	}
+	target := min(max(int(math.Round(fill*float64(2*bt.t-1))), bt.t-1), 2*bt.t-1)
+
+	var children []*BTreeNode[K, V]
+	for {
+		sizes := bt.nodeSizes(len(keys), target)
+		nodes := make([]*BTreeNode[K, V], 0, len(sizes))
+		var upKeys []K
+		var upValues []V
+		pos, child := 0, 0
+		for i, size := range sizes {
+			node := &BTreeNode[K, V]{
+				leaf:   children == nil,
+				keys:   slices.Clone(keys[pos : pos+size]),
+				values: slices.Clone(values[pos : pos+size]),
+			}
+			if children != nil {
+				node.childs = slices.Clone(children[child : child+size+1])
+				child += size + 1
+			}
+			pos += size
+			if i < len(sizes)-1 {
+				// Promote the key between this node and the next.
+				upKeys = append(upKeys, keys[pos])
+				upValues = append(upValues, values[pos])
+				pos++
+			}
+			nodes = append(nodes, node)
+		}
+		if len(nodes) == 1 {
+			bt.root = nodes[0]
+			return
+		}
+		keys, values, children = upKeys, upValues, nodes
+	}
+}
+
+// nodeSizes splits n keys into the key counts of the nodes of one level,
+// leaving one key between consecutive nodes to promote to the level above.
+// Nodes get about target keys each, and the counts differ by at most one.
+// When there are several nodes, each has at least t-1 and at most 2t-1 keys.
+func (bt *BTree[K, V]) nodeSizes(n, target int) []int {
+	// Each node but the last uses its keys plus one separator, so n keys
+	// fill ceil((n+1) / (target+1)) nodes.
+	count := (n + target + 1) / (target + 1)
+	// Never spread the keys so thin that nodes drop below t-1 keys.
+	count = max(min(count, (n+1)/bt.t), 1)
+
+	sizes := make([]int, count)
+	total := n - (count - 1)
+	for i := range sizes {
+		sizes[i] = total / count
+		if i < total%count {
+			sizes[i]++
+		}
+	}
+	return sizes
+}
@@ -0,0 +1,113 @@
+package btree
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// sequence returns the keys 0, 2, ..., 2(n-1) mapped to twice their value.
+func sequence(n int) ([]int, []int) {
+	keys, values := make([]int, n), make([]int, n)
+	for i := range keys {
+		keys[i], values[i] = 2*i, 4*i
+	}
+	return keys, values
+}
+
+func TestBulkLoadBuildsValidTrees(t *testing.T) {
+	for _, degree := range []int{2, 3, 7} {
+		for _, fill := range []float64{0, 0.01, 0.5, 0.75, 1} {
+			for n := 0; n <= 300; n += 1 + n/10 {
+				keys, values := sequence(n)
+				bt, err := BulkLoadSlice(degree, fill, keys, values)
+				if err != nil {
+					t.Fatalf("t=%d fill=%v n=%d: %v", degree, fill, n, err)
+				}
+				if got := checkTree(t, bt); got != n || bt.Len() != n {
+					t.Fatalf("t=%d fill=%v n=%d: expected %d keys, got %d", degree, fill, n, n, got)
+				}
+				if got := collect(t, bt.All()); !reflect.DeepEqual(got, keys) && n > 0 {
+					t.Fatalf("t=%d fill=%v n=%d: expected %v, got %v", degree, fill, n, keys, got)
+				}
+			}
+		}
+	}
+}
+
+func TestBulkLoadFillFactor(t *testing.T) {
+	keys, values := sequence(1000)
+	full, _ := BulkLoadSlice(50, 1, keys, values)
+	half, _ := BulkLoadSlice(50, 0.5, keys, values)
+
+	inserted := NewBTree[int, int](50)
+	for i, k := range keys {
+		inserted.Put(k, values[i])
+	}
+
+	// 1000 keys fill 11 leaves of at most 99 keys under a single root.
+	if full.Height() != 2 || len(full.root.childs) != 11 {
+		t.Fatalf("expected 11 full leaves under the root, got height %d with %d children", full.Height(), len(full.root.childs))
+	}
+	if len(half.root.childs) <= len(full.root.childs) || len(inserted.root.childs) <= len(full.root.childs) {
+		t.Fatal("expected lower fill and one-by-one insertion to use more leaves")
+	}
+
+	// A loaded tree keeps working as usual.
+	half.Put(1, 2)
+	half.Delete(500)
+	if checkTree(t, half) != 1000 || !half.Search(1) || half.Search(500) {
+		t.Fatal("expected the loaded tree to accept insertions and deletions")
+	}
+}
+
+func TestBulkLoadErrors(t *testing.T) {
+	_, err := BulkLoadSlice(3, 1, []int{1, 2, 5, 4}, []string{"a", "b", "c", "d"})
+	if !errors.Is(err, ErrUnsorted) || !strings.Contains(err.Error(), "position 3") {
+		t.Fatalf("expected ErrUnsorted at position 3, got %v", err)
+	}
+	if _, err := BulkLoadSlice(3, 1, []int{1, 2, 2}, []int{1, 2, 3}); !errors.Is(err, ErrUnsorted) {
+		t.Fatalf("expected duplicates to be rejected, got %v", err)
+	}
+	if _, err := BulkLoadSlice(3, 1, []int{1, 2}, []int{1}); !errors.Is(err, ErrLengthMismatch) {
+		t.Fatalf("expected ErrLengthMismatch, got %v", err)
+	}
+	for _, fill := range []float64{-0.1, 1.5} {
+		if _, err := BulkLoadSlice(3, fill, []int{1}, []int{1}); !errors.Is(err, ErrFillFactor) {
+			t.Fatalf("expected ErrFillFactor for %v, got %v", fill, err)
+		}
+	}
+}
+
+func TestBulkLoadIterator(t *testing.T) {
+	words := []string{"cherry", "Apple", "banana"}
+	slices.SortFunc(words, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	bt, err := BulkLoadFunc(2, 1, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}, func(yield func(string, int) bool) {
+		for i, w := range words {
+			if !yield(w, i) {
+				return
+			}
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := bt.Get("APPLE"); !ok || v != 0 {
+		t.Fatalf("expected APPLE to find Apple, got %d, %v", v, ok)
+	}
+
+	rows := []string{"r0", "r1", "r2", "r3", "r4"}
+	byIndex, err := BulkLoad(2, 0.5, slices.All(rows))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := byIndex.Get(3); v != "r3" || byIndex.Len() != 5 {
+		t.Fatalf("expected row 3 to be r3, got %q", v)
+	}
+}
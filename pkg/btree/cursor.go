@@ -0,0 +1,317 @@
+package btree
+
+// Cursor walks the keys of a B-Tree in either direction from any position,
+// and can delete the entry it points at without losing its place.
+//
+// A cursor keeps the path from the root to its current entry. Every change
+// to the tree may split, borrow between or merge the nodes on that path, so
+// the cursor also remembers its current key: when it notices that the tree
+// has changed since it last moved, it finds its key again before moving. If
+// that key has been deleted in the meantime, Next moves to the first key
+// after it and Prev to the last key before it.
+//
+// A cursor is positioned by First, Last or Seek. Until then, and after
+// moving past either end, it is invalid: Valid returns false and Key and
+// Value return zero values.
+type Cursor[K, V any] struct {
+	tree  *BTree[K, V]
+	stack []cursorFrame[K, V] // Path from the root; the top frame holds the current entry
+	key   K                   // Current key, kept to re-seek after the tree changes
+	value V                   // Current value as of the last move
+	valid bool
+
+	version int  // Tree version the stack was built against
+	gone    bool // The current key was deleted; the stack points at its successor
+}
+
+// cursorFrame is one node on a cursor's path. For the top frame, index is
+// the position of the current key. For the frames above it, index is the
+// child the path descends into.
+type cursorFrame[K, V any] struct {
+	node  *BTreeNode[K, V]
+	index int
+}
+
+// Cursor returns a new, unpositioned cursor over the B-Tree.
+//
+// Time complexity: O(1)
+// Space complexity: O(log_t(n)) once positioned
+//
+// Example:
+//
+//	c := bt.Cursor()
+//	for ok := c.First(); ok; ok = c.Next() {
+//		fmt.Println(c.Key(), c.Value())
+//	}
+func (bt *BTree[K, V]) Cursor() *Cursor[K, V] {
+	return &Cursor[K, V]{tree: bt}
+}
+
+// Valid reports whether the cursor points at an entry.
+func (c *Cursor[K, V]) Valid() bool {
+	return c.valid
+}
+
+// Key returns the key the cursor points at, or the zero value if the cursor
+// is invalid.
+func (c *Cursor[K, V]) Key() K {
+	return c.key
+}
+
+// Value returns the value stored under the cursor's key, or the zero value
+// if the cursor is invalid. If the key has been deleted since the cursor
+// last moved, it returns the value the key had then.
+//
+// Time complexity: O(1), or O(log t * log_t(n)) after the tree changed
+// Space complexity: O(1)
+func (c *Cursor[K, V]) Value() V {
+	if c.sync() && !c.gone {
+		top := c.stack[len(c.stack)-1]
+		c.value = top.node.values[top.index]
+	}
+	return c.value
+}
+
+// First moves the cursor to the smallest key.
+//
+// Returns:
+//   - bool: false if the tree is empty
+//
+// Time complexity: O(log_t(n))
+// Space complexity: O(log_t(n))
+func (c *Cursor[K, V]) First() bool {
+	c.reset()
+	for node := c.tree.root; node != nil; {
+		c.stack = append(c.stack, cursorFrame[K, V]{node: node})
+		if node.leaf {
+			break
+		}
+		node = node.childs[0]
+	}
+	return c.settle()
+}
+
+// Last moves the cursor to the largest key.
+//
+// Returns:
+//   - bool: false if the tree is empty
+//
+// Time complexity: O(log_t(n))
+// Space complexity: O(log_t(n))
+func (c *Cursor[K, V]) Last() bool {
+	c.reset()
+	c.pushLast(c.tree.root)
+	return c.settle()
+}
+
+// Seek moves the cursor to the smallest key greater than or equal to key.
+//
+// Parameters:
+//   - key: The key to seek to
+//
+// Returns:
+//   - bool: false if every key in the tree is less than key
+//
+// Time complexity: O(log t * log_t(n))
+// Space complexity: O(log_t(n))
+//
+// Example:
+//
+//	// Tree contains: 10, 20, 30
+//	c := bt.Cursor()
+//	c.Seek(15)  // returns true; c.Key() == 20
+func (c *Cursor[K, V]) Seek(key K) bool {
+	c.seek(key)
+	return c.settle()
+}
+
+// Next moves the cursor to the next larger key.
+//
+// Returns:
+//   - bool: false if the cursor was at the largest key or invalid; the
+//     cursor is then invalid
+//
+// Time complexity: O(log_t(n)) worst case, O(1) amortized over a full scan
+// Space complexity: O(1)
+func (c *Cursor[K, V]) Next() bool {
+	if !c.sync() {
+		return false
+	}
+	if c.gone {
+		// The stack already points at the successor of the deleted key.
+		return c.settle()
+	}
+
+	top := &c.stack[len(c.stack)-1]
+	if !top.node.leaf {
+		// The successor is the leftmost key of the right subtree.
+		top.index++
+		node := top.node.childs[top.index]
+		for {
+			c.stack = append(c.stack, cursorFrame[K, V]{node: node})
+			if node.leaf {
+				break
+			}
+			node = node.childs[0]
+		}
+		return c.settle()
+	}
+
+	top.index++
+	c.climbRight()
+	return c.settle()
+}
+
+// Prev moves the cursor to the next smaller key.
+//
+// Returns:
+//   - bool: false if the cursor was at the smallest key or invalid; the
+//     cursor is then invalid
+//
+// Time complexity: O(log_t(n)) worst case, O(1) amortized over a full scan
+// Space complexity: O(1)
+func (c *Cursor[K, V]) Prev() bool {
+	if !c.sync() {
+		return false
+	}
+	if len(c.stack) == 0 {
+		// The current key was deleted and nothing follows it.
+		return c.Last()
+	}
+
+	// If the current key is gone, the path points at its successor, whose
+	// predecessor is the key we want.
+	top := &c.stack[len(c.stack)-1]
+	if !top.node.leaf {
+		// The predecessor is the rightmost key of the left subtree.
+		c.pushLast(top.node.childs[top.index])
+		return c.settle()
+	}
+
+	top.index--
+	for len(c.stack) > 0 && c.stack[len(c.stack)-1].index < 0 {
+		c.stack = c.stack[:len(c.stack)-1]
+		if len(c.stack) > 0 {
+			// Leaving childs[i] upwards lands just after keys[i-1].
+			c.stack[len(c.stack)-1].index--
+		}
+	}
+	return c.settle()
+}
+
+// DeleteCurrent removes the entry the cursor points at and moves the cursor
+// to the next larger key, so that a loop can delete while it walks. The
+// borrows and merges done by the deletion do not invalidate the cursor.
+//
+// Returns:
+//   - bool: false if there was nothing to delete: the cursor was invalid,
+//     or its key had already been deleted through the tree
+//
+// Time complexity: O(t * log_t(n))
+// Space complexity: O(log_t(n))
+//
+// Example:
+//
+//	// Delete every odd key
+//	c := bt.Cursor()
+//	for c.First(); c.Valid(); {
+//		if c.Key()%2 == 1 {
+//			c.DeleteCurrent()
+//		} else {
+//			c.Next()
+//		}
+//	}
+func (c *Cursor[K, V]) DeleteCurrent() bool {
+	if !c.sync() {
+		return false
+	}
+	key, deleted := c.key, false
+	if !c.gone {
+		_, deleted = c.tree.Delete(key)
+	}
+	c.seek(key)
+	c.settle()
+	return deleted
+}
+
+// reset clears the cursor's path before repositioning it.
+func (c *Cursor[K, V]) reset() {
+	clear(c.stack)
+	c.stack = c.stack[:0]
+	c.gone = false
+	c.version = c.tree.version
+}
+
+// seek builds the path to the smallest key greater than or equal to key.
+func (c *Cursor[K, V]) seek(key K) {
+	c.reset()
+	for node := c.tree.root; node != nil; {
+		i, found := c.tree.find(node, key)
+		c.stack = append(c.stack, cursorFrame[K, V]{node: node, index: i})
+		if found || node.leaf {
+			break
+		}
+		node = node.childs[i]
+	}
+	c.climbRight()
+}
+
+// pushLast extends the path to the largest key in the subtree rooted at
+// node.
+func (c *Cursor[K, V]) pushLast(node *BTreeNode[K, V]) {
+	for node != nil {
+		if node.leaf {
+			c.stack = append(c.stack, cursorFrame[K, V]{node: node, index: len(node.keys) - 1})
+			return
+		}
+		c.stack = append(c.stack, cursorFrame[K, V]{node: node, index: len(node.childs) - 1})
+		node = node.childs[len(node.childs)-1]
+	}
+}
+
+// climbRight pops frames whose index has run past their last key. Leaving
+// childs[i] upwards lands on keys[i], the next key in order.
+func (c *Cursor[K, V]) climbRight() {
+	for len(c.stack) > 0 {
+		top := c.stack[len(c.stack)-1]
+		if top.index < len(top.node.keys) {
+			return
+		}
+		c.stack = c.stack[:len(c.stack)-1]
+	}
+}
+
+// settle records the entry at the top of the path as the current one, or
+// marks the cursor invalid if the path is empty.
+func (c *Cursor[K, V]) settle() bool {
+	c.gone = false
+	if len(c.stack) == 0 {
+		var key K
+		var value V
+		c.key, c.value, c.valid = key, value, false
+		return false
+	}
+	top := c.stack[len(c.stack)-1]
+	c.key, c.value, c.valid = top.node.keys[top.index], top.node.values[top.index], true
+	return true
+}
+
+// sync rebuilds the path if the tree has changed since the cursor last
+// moved. If the current key is gone, the path points at its successor and
+// gone is set. It returns false if the cursor is invalid.
+func (c *Cursor[K, V]) sync() bool {
+	if !c.valid {
+		return false
+	}
+	if c.version == c.tree.version {
+		return true
+	}
+	c.seek(c.key)
+	if len(c.stack) == 0 {
+		c.gone = true
+		return true
+	}
+	top := c.stack[len(c.stack)-1]
+	c.gone = c.tree.compare(top.node.keys[top.index], c.key) != 0
+	return true
+}
@@ -0,0 +1,167 @@
+package btree
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestCursorScans(t *testing.T) {
+	bt, keys := randomTree(2, 300, 5)
+	c := bt.Cursor()
+	if c.Valid() || c.Next() || c.Prev() {
+		t.Fatal("expected a new cursor to be invalid")
+	}
+
+	var forward []int
+	for ok := c.First(); ok; ok = c.Next() {
+		if c.Value() != 2*c.Key() {
+			t.Fatalf("key %d has value %d", c.Key(), c.Value())
+		}
+		forward = append(forward, c.Key())
+	}
+	if !reflect.DeepEqual(forward, keys) {
+		t.Fatalf("expected a forward scan to visit %d sorted keys, got %v", len(keys), forward)
+	}
+	if c.Valid() || c.Key() != 0 {
+		t.Fatal("expected the cursor to be invalid past the last key")
+	}
+
+	var backward []int
+	for ok := c.Last(); ok; ok = c.Prev() {
+		backward = append(backward, c.Key())
+	}
+	slices.Reverse(backward)
+	if !reflect.DeepEqual(backward, keys) {
+		t.Fatalf("expected a backward scan to visit %d keys, got %v", len(keys), backward)
+	}
+
+	// Zig-zag from the middle.
+	c.Seek(keys[150])
+	for i := 0; i < 40; i++ {
+		c.Next()
+		c.Next()
+		c.Prev()
+	}
+	if c.Key() != keys[190] {
+		t.Fatalf("expected to end at %d, got %d", keys[190], c.Key())
+	}
+
+	empty := NewBTree[int, int](2).Cursor()
+	if empty.First() || empty.Last() || empty.Seek(1) {
+		t.Fatal("expected cursors over an empty tree to stay invalid")
+	}
+}
+
+func TestCursorSeek(t *testing.T) {
+	bt, keys := randomTree(3, 200, 6)
+	c := bt.Cursor()
+	for q := -1; q <= 801; q++ {
+		i, _ := slices.BinarySearch(keys, q)
+		ok := c.Seek(q)
+		if ok != (i < len(keys)) || (ok && c.Key() != keys[i]) {
+			t.Fatalf("Seek(%d): expected index %d, got %d, %v", q, i, c.Key(), ok)
+		}
+		if ok && i > 0 {
+			if !c.Prev() || c.Key() != keys[i-1] {
+				t.Fatalf("Seek(%d) then Prev: expected %d, got %d", q, keys[i-1], c.Key())
+			}
+		}
+	}
+}
+
+func TestCursorDeleteCurrent(t *testing.T) {
+	for _, degree := range []int{2, 3, 4} {
+		bt, keys := randomTree(degree, 400, int64(degree))
+		c := bt.Cursor()
+		var kept []int
+		for c.First(); c.Valid(); {
+			if c.Key()%3 != 0 {
+				key := c.Key()
+				if !c.DeleteCurrent() {
+					t.Fatalf("expected %d to be deleted", key)
+				}
+				if c.Valid() && c.Key() <= key {
+					t.Fatalf("expected the cursor to move past %d, got %d", key, c.Key())
+				}
+			} else {
+				kept = append(kept, c.Key())
+				c.Next()
+			}
+		}
+
+		var want []int
+		for _, k := range keys {
+			if k%3 == 0 {
+				want = append(want, k)
+			}
+		}
+		if !reflect.DeepEqual(kept, want) || !reflect.DeepEqual(collect(t, bt.All()), want) {
+			t.Fatalf("degree %d: expected only multiples of 3 to remain", degree)
+		}
+		if n := checkTree(t, bt); n != len(want) || bt.Len() != len(want) {
+			t.Fatalf("degree %d: expected %d keys, got %d", degree, len(want), n)
+		}
+
+		// Delete the rest from the back.
+		c.Last()
+		for c.Valid() {
+			c.DeleteCurrent()
+			c.Last()
+		}
+		if bt.Len() != 0 || c.DeleteCurrent() {
+			t.Fatal("expected an empty tree and nothing left to delete")
+		}
+	}
+}
+
+func TestCursorSurvivesTreeChanges(t *testing.T) {
+	bt := NewBTree[int, int](2)
+	for i := 0; i < 100; i += 10 {
+		bt.Put(i, 2*i)
+	}
+	c := bt.Cursor()
+	c.Seek(50)
+
+	// New keys are seen by the next move.
+	bt.Put(55, 110)
+	if !c.Next() || c.Key() != 55 {
+		t.Fatalf("expected to move to the inserted 55, got %d", c.Key())
+	}
+	bt.Put(55, 1)
+	if c.Value() != 1 {
+		t.Fatalf("expected the replaced value, got %d", c.Value())
+	}
+
+	// If the current key is deleted, Next and Prev move around the gap.
+	bt.Delete(55)
+	if c.Key() != 55 || c.Value() != 1 {
+		t.Fatal("expected the cursor to keep the deleted entry until it moves")
+	}
+	if !c.Next() || c.Key() != 60 {
+		t.Fatalf("expected to move to 60, got %d", c.Key())
+	}
+	bt.Delete(60)
+	if !c.Prev() || c.Key() != 50 {
+		t.Fatalf("expected to move back to 50, got %d", c.Key())
+	}
+	if c.DeleteCurrent(); c.Key() != 70 {
+		t.Fatalf("expected DeleteCurrent to move to 70, got %d", c.Key())
+	}
+	bt.Delete(70)
+	if c.DeleteCurrent() || c.Key() != 80 {
+		t.Fatalf("expected no deletion and a move to 80, got %d", c.Key())
+	}
+
+	// Deleting the last key, then stepping back.
+	c.Last()
+	bt.Delete(90)
+	if !c.Prev() || c.Key() != 80 {
+		t.Fatalf("expected to move back to 80, got %d", c.Key())
+	}
+	c.Last()
+	bt.Delete(80)
+	if c.Next() {
+		t.Fatal("expected no key after the deleted last key")
+	}
+}
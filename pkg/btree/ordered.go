@@ -0,0 +1,313 @@
+package btree
+
+import "iter"
+
+// boundKind says whether a Bound includes its key, excludes it, or is open.
+type boundKind int
+
+const (
+	unbounded boundKind = iota
+	inclusive
+	exclusive
+)
+
+// Bound is one end of a key range passed to Range. The zero Bound is
+// unbounded.
+type Bound[K any] struct {
+	key  K
+	kind boundKind
+}
+
+// Inclusive returns a bound that includes key.
+func Inclusive[K any](key K) Bound[K] {
+	return Bound[K]{key: key, kind: inclusive}
+}
+
+// Exclusive returns a bound that excludes key.
+func Exclusive[K any](key K) Bound[K] {
+	return Bound[K]{key: key, kind: exclusive}
+}
+
+// Unbounded returns a bound that leaves its end of the range open.
+func Unbounded[K any]() Bound[K] {
+	return Bound[K]{}
+}
+
+// Len returns the number of keys stored in the B-Tree.
+//
+// Time complexity: O(1)
+// Space complexity: O(1)
+func (bt *BTree[K, V]) Len() int {
+	return bt.size
+}
+
+// Height returns the number of levels in the B-Tree: 0 when it is empty and
+// 1 when the root is a leaf. Since all leaves are at the same depth, it is
+// the length of any root-to-leaf path.
+//
+// Time complexity: O(log_t(n))
+// Space complexity: O(1)
+func (bt *BTree[K, V]) Height() int {
+	height := 0
+	for node := bt.root; node != nil; height++ {
+		if node.leaf {
+			node = nil
+		} else {
+			node = node.childs[0]
+		}
+	}
+	return height
+}
+
+// All returns an iterator over the key-value pairs of the B-Tree in
+// ascending key order. Ranging stops early when the loop body breaks.
+//
+// The tree must not be modified while ranging; use a Cursor to delete
+// while walking.
+//
+// Time complexity: O(n) for a full traversal
+// Space complexity: O(log_t(n)) for recursion
+//
+// Example:
+//
+//	for k, v := range bt.All() {
+//		fmt.Println(k, v)
+//	}
+func (bt *BTree[K, V]) All() iter.Seq2[K, V] {
+	return bt.Range(Unbounded[K](), Unbounded[K]())
+}
+
+// Backward returns an iterator over the key-value pairs of the B-Tree in
+// descending key order. Ranging stops early when the loop body breaks.
+//
+// Time complexity: O(n) for a full traversal
+// Space complexity: O(log_t(n)) for recursion
+func (bt *BTree[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if bt.root != nil {
+			bt.descend(bt.root, yield)
+		}
+	}
+}
+
+// Range returns an iterator over the key-value pairs with keys between lo
+// and hi, in ascending key order. Each bound is built with Inclusive,
+// Exclusive or Unbounded; a range whose lo is above its hi is empty.
+// Subtrees entirely outside the range are skipped.
+//
+// Parameters:
+//   - lo: The lower bound of the range
+//   - hi: The upper bound of the range
+//
+// Returns:
+//   - iter.Seq2[K, V]: An iterator over the pairs in the range
+//
+// Time complexity: O(t * log_t(n) + m) where m is the number of pairs yielded
+// Space complexity: O(log_t(n)) for recursion
+//
+// Example:
+//
+//	// Keys k with 10 <= k < 20
+//	for k, v := range bt.Range(btree.Inclusive(10), btree.Exclusive(20)) {
+//		fmt.Println(k, v)
+//	}
+func (bt *BTree[K, V]) Range(lo, hi Bound[K]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if bt.root != nil {
+			bt.ascend(bt.root, lo, hi, yield)
+		}
+	}
+}
+
+// ascend yields the pairs of the subtree rooted at node that lie within
+// [lo, hi] in ascending order. It returns false once the range is exhausted
+// or yield asks to stop, so that callers stop too.
+func (bt *BTree[K, V]) ascend(node *BTreeNode[K, V], lo, hi Bound[K], yield func(K, V) bool) bool {
+	start, skipChild := 0, false
+	if lo.kind != unbounded {
+		i, found := bt.find(node, lo.key)
+		start = i
+		if found {
+			if lo.kind == inclusive {
+				// keys[i] is lo itself, so childs[i] holds only smaller keys.
+				skipChild = true
+			} else {
+				start++
+			}
+		}
+	}
+
+	for i := start; i <= len(node.keys); i++ {
+		if !node.leaf && !(i == start && skipChild) {
+			if !bt.ascend(node.childs[i], lo, hi, yield) {
+				return false
+			}
+		}
+		if i == len(node.keys) {
+			break
+		}
+		if !bt.belowHigh(node.keys[i], hi) || !yield(node.keys[i], node.values[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// descend yields the pairs of the subtree rooted at node in descending
+// order, returning false once yield asks to stop.
+func (bt *BTree[K, V]) descend(node *BTreeNode[K, V], yield func(K, V) bool) bool {
+	for i := len(node.keys); i >= 0; i-- {
+		if !node.leaf && !bt.descend(node.childs[i], yield) {
+			return false
+		}
+		if i > 0 && !yield(node.keys[i-1], node.values[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// belowHigh reports whether key lies on the low side of hi.
+func (bt *BTree[K, V]) belowHigh(key K, hi Bound[K]) bool {
+	switch hi.kind {
+	case inclusive:
+		return bt.compare(key, hi.key) <= 0
+	case exclusive:
+		return bt.compare(key, hi.key) < 0
+	}
+	return true
+}
+
+// Min returns the smallest key in the B-Tree and its value.
+//
+// Returns:
+//   - K: The smallest key
+//   - V: Its value
+//   - bool: false if the tree is empty
+//
+// Time complexity: O(log_t(n))
+// Space complexity: O(1)
+func (bt *BTree[K, V]) Min() (K, V, bool) {
+	if bt.root == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	node := bt.root
+	for !node.leaf {
+		node = node.childs[0]
+	}
+	return node.keys[0], node.values[0], true
+}
+
+// Max returns the largest key in the B-Tree and its value.
+//
+// Returns:
+//   - K: The largest key
+//   - V: Its value
+//   - bool: false if the tree is empty
+//
+// Time complexity: O(log_t(n))
+// Space complexity: O(1)
+func (bt *BTree[K, V]) Max() (K, V, bool) {
+	if bt.root == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	node := bt.root
+	for !node.leaf {
+		node = node.childs[len(node.childs)-1]
+	}
+	last := len(node.keys) - 1
+	return node.keys[last], node.values[last], true
+}
+
+// Floor returns the largest key less than or equal to key, and its value.
+//
+// Parameters:
+//   - key: The key to search from
+//
+// Returns:
+//   - K: The floor key
+//   - V: Its value
+//   - bool: false if every key in the tree is greater than key
+//
+// Time complexity: O(log t * log_t(n))
+// Space complexity: O(1)
+//
+// Example:
+//
+//	// Tree contains: 10, 20, 30
+//	k, _, ok := bt.Floor(25)  // returns 20, true
+//	k, _, ok = bt.Floor(5)    // returns 0, false
+func (bt *BTree[K, V]) Floor(key K) (K, V, bool) {
+	var (
+		best  *BTreeNode[K, V]
+		index int
+	)
+	for node := bt.root; node != nil; {
+		i, found := bt.find(node, key)
+		if found {
+			return node.keys[i], node.values[i], true
+		}
+		if i > 0 {
+			best, index = node, i-1
+		}
+		if node.leaf {
+			break
+		}
+		node = node.childs[i]
+	}
+	if best == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return best.keys[index], best.values[index], true
+}
+
+// Ceiling returns the smallest key greater than or equal to key, and its
+// value.
+//
+// Parameters:
+//   - key: The key to search from
+//
+// Returns:
+//   - K: The ceiling key
+//   - V: Its value
+//   - bool: false if every key in the tree is less than key
+//
+// Time complexity: O(log t * log_t(n))
+// Space complexity: O(1)
+//
+// Example:
+//
+//	// Tree contains: 10, 20, 30
+//	k, _, ok := bt.Ceiling(25)  // returns 30, true
+//	k, _, ok = bt.Ceiling(35)   // returns 0, false
+func (bt *BTree[K, V]) Ceiling(key K) (K, V, bool) {
+	var (
+		best  *BTreeNode[K, V]
+		index int
+	)
+	for node := bt.root; node != nil; {
+		i, found := bt.find(node, key)
+		if found {
+			return node.keys[i], node.values[i], true
+		}
+		if i < len(node.keys) {
+			best, index = node, i
+		}
+		if node.leaf {
+			break
+		}
+		node = node.childs[i]
+	}
+	if best == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return best.keys[index], best.values[index], true
+}
@@ -0,0 +1,172 @@
+package btree
+
+import (
+	"iter"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// randomTree fills a tree of the given degree with n distinct random keys
+// below 4n, each mapped to twice its value, and returns the sorted keys.
+func randomTree(degree, n int, seed int64) (*BTree[int, int], []int) {
+	bt := NewBTree[int, int](degree)
+	keys := rand.New(rand.NewSource(seed)).Perm(4 * n)[:n]
+	for _, k := range keys {
+		bt.Put(k, 2*k)
+	}
+	slices.Sort(keys)
+	return bt, keys
+}
+
+// collect returns the keys seq yields, checking that each value is twice
+// its key as randomTree stores them.
+func collect(t *testing.T, seq iter.Seq2[int, int]) []int {
+	t.Helper()
+	var keys []int
+	for k, v := range seq {
+		if v != 2*k {
+			t.Fatalf("key %d has value %d", k, v)
+		}
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestAllAndBackward(t *testing.T) {
+	bt, keys := randomTree(3, 300, 1)
+	if got := collect(t, bt.All()); !reflect.DeepEqual(got, keys) {
+		t.Fatalf("All: expected %d sorted keys, got %v", len(keys), got)
+	}
+	backward := slices.Clone(keys)
+	slices.Reverse(backward)
+	if got := collect(t, bt.Backward()); !reflect.DeepEqual(got, backward) {
+		t.Fatalf("Backward: expected %d keys in reverse order, got %v", len(keys), got)
+	}
+
+	// Breaking out of the loop stops the traversal.
+	var first []int
+	for k := range bt.All() {
+		if len(first) == 5 {
+			break
+		}
+		first = append(first, k)
+	}
+	if !reflect.DeepEqual(first, keys[:5]) {
+		t.Fatalf("expected %v, got %v", keys[:5], first)
+	}
+
+	empty := NewBTree[int, int](2)
+	if len(collect(t, empty.All())) != 0 || len(collect(t, empty.Backward())) != 0 {
+		t.Fatal("expected an empty tree to yield nothing")
+	}
+}
+
+func TestRange(t *testing.T) {
+	bt, keys := randomTree(2, 200, 2)
+	r := rand.New(rand.NewSource(3))
+	bound := func() (Bound[int], func(k int, low bool) bool) {
+		key := r.Intn(850) - 25
+		switch r.Intn(3) {
+		case 0:
+			return Inclusive(key), func(k int, low bool) bool {
+				return (low && k >= key) || (!low && k <= key)
+			}
+		case 1:
+			return Exclusive(key), func(k int, low bool) bool {
+				return (low && k > key) || (!low && k < key)
+			}
+		}
+		return Unbounded[int](), func(int, bool) bool { return true }
+	}
+
+	for i := 0; i < 500; i++ {
+		lo, inLo := bound()
+		hi, inHi := bound()
+		var want []int
+		for _, k := range keys {
+			if inLo(k, true) && inHi(k, false) {
+				want = append(want, k)
+			}
+		}
+		if got := collect(t, bt.Range(lo, hi)); !reflect.DeepEqual(got, want) {
+			t.Fatalf("Range(%v, %v): expected %v, got %v", lo, hi, want, got)
+		}
+	}
+
+	// Bounds on keys that are present.
+	k0, k1 := keys[10], keys[20]
+	if got := collect(t, bt.Range(Inclusive(k0), Inclusive(k1))); !reflect.DeepEqual(got, keys[10:21]) {
+		t.Fatalf("expected %v, got %v", keys[10:21], got)
+	}
+	if got := collect(t, bt.Range(Exclusive(k0), Exclusive(k1))); !reflect.DeepEqual(got, keys[11:20]) {
+		t.Fatalf("expected %v, got %v", keys[11:20], got)
+	}
+}
+
+func TestMinMaxFloorCeiling(t *testing.T) {
+	bt, keys := randomTree(4, 500, 4)
+	if k, v, ok := bt.Min(); !ok || k != keys[0] || v != 2*k {
+		t.Fatalf("Min: expected %d, got %d, %v", keys[0], k, ok)
+	}
+	if k, _, ok := bt.Max(); !ok || k != keys[len(keys)-1] {
+		t.Fatalf("Max: expected %d, got %d, %v", keys[len(keys)-1], k, ok)
+	}
+
+	for q := -5; q < 2005; q++ {
+		i, found := slices.BinarySearch(keys, q)
+		floor, floorOK := 0, found || i > 0
+		if found {
+			floor = keys[i]
+		} else if i > 0 {
+			floor = keys[i-1]
+		}
+		ceiling, ceilingOK := 0, i < len(keys)
+		if ceilingOK {
+			ceiling = keys[i]
+		}
+		if k, v, ok := bt.Floor(q); ok != floorOK || k != floor || (ok && v != 2*k) {
+			t.Fatalf("Floor(%d): expected %d, %v; got %d, %v", q, floor, floorOK, k, ok)
+		}
+		if k, _, ok := bt.Ceiling(q); ok != ceilingOK || k != ceiling {
+			t.Fatalf("Ceiling(%d): expected %d, %v; got %d, %v", q, ceiling, ceilingOK, k, ok)
+		}
+	}
+
+	empty := NewBTree[int, int](2)
+	if _, _, ok := empty.Min(); ok {
+		t.Fatal("expected Min of an empty tree to fail")
+	}
+	if _, _, ok := empty.Floor(1); ok {
+		t.Fatal("expected Floor of an empty tree to fail")
+	}
+}
+
+func TestLenAndHeight(t *testing.T) {
+	bt := NewBTree[int, int](2)
+	if bt.Len() != 0 || bt.Height() != 0 {
+		t.Fatal("expected an empty tree to have no keys and no levels")
+	}
+	bt.Put(1, 1)
+	if bt.Len() != 1 || bt.Height() != 1 {
+		t.Fatalf("expected one key on one level, got %d on %d", bt.Len(), bt.Height())
+	}
+	for i := 0; i < 1000; i++ {
+		bt.Put(i%700, i)
+	}
+	if bt.Len() != 700 {
+		t.Fatalf("expected replaced keys not to count twice, got %d", bt.Len())
+	}
+	// With t = 2 every node has at least 2 children, so height <= log2(n) + 1.
+	if h := bt.Height(); h < 5 || h > 10 {
+		t.Fatalf("expected a height between 5 and 10, got %d", h)
+	}
+	for i := 0; i < 700; i += 2 {
+		bt.Remove(i)
+	}
+	bt.Remove(-1)
+	if bt.Len() != 350 || checkTree(t, bt) != 350 {
+		t.Fatalf("expected 350 keys after removals, got %d", bt.Len())
+	}
+}
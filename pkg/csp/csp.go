@@ -0,0 +1,199 @@
+// Package csp provides a small solver for constraint satisfaction problems
+// (CSPs) made of named variables, finite domains and binary constraints.
+//
+// Problems are solved with depth-first backtracking search using the
+// minimum-remaining-values (MRV) heuristic to pick the next variable and
+// forward checking to prune the domains of unassigned neighbours after
+// every assignment. When the search space is exhausted without finding an
+// assignment, the problem is proven unsatisfiable.
+package csp
+
+// Problem represents a constraint satisfaction problem whose variables take
+// values of type T.
+type Problem[T any] struct {
+	names   []string       // Variable names in insertion order
+	index   map[string]int // Variable name to position in names
+	domains [][]T          // Candidate values for each variable, tried in order
+	arcs    [][]arc[T]     // Constraints seen from each variable
+}
+
+// arc is a binary constraint seen from one of its two variables.
+type arc[T any] struct {
+	other   int
+	allowed func(self, other T) bool
+}
+
+// NewProblem creates an empty constraint satisfaction problem.
+func NewProblem[T any]() *Problem[T] {
+	return &Problem[T]{index: make(map[string]int)}
+}
+
+// AddVariable adds a variable with the given domain. Values are tried in the
+// order they appear in domain. It returns false if the name is empty or the
+// variable already exists.
+func (p *Problem[T]) AddVariable(name string, domain []T) bool {
+	if name == "" {
+		return false
+	}
+	if _, ok := p.index[name]; ok {
+		return false
+	}
+
+	p.index[name] = len(p.names)
+	p.names = append(p.names, name)
+	p.domains = append(p.domains, append([]T(nil), domain...))
+	p.arcs = append(p.arcs, nil)
+	return true
+}
+
+// AddConstraint adds a binary constraint between variables a and b.
+// allowed receives the value of a and the value of b and reports whether the
+// pair is consistent. It returns false if either variable is missing, if a
+// equals b or if allowed is nil.
+func (p *Problem[T]) AddConstraint(a, b string, allowed func(x, y T) bool) bool {
+	if allowed == nil || a == b {
+		return false
+	}
+
+	ai, okA := p.index[a]
+	bi, okB := p.index[b]
+	if !okA || !okB {
+		return false
+	}
+
+	p.arcs[ai] = append(p.arcs[ai], arc[T]{other: bi, allowed: allowed})
+	p.arcs[bi] = append(p.arcs[bi], arc[T]{other: ai, allowed: func(self, other T) bool {
+		return allowed(other, self)
+	}})
+	return true
+}
+
+// Variables returns the variable names in insertion order.
+func (p *Problem[T]) Variables() []string {
+	return append([]string(nil), p.names...)
+}
+
+// Solve searches for an assignment that satisfies every constraint.
+// It returns (nil, false) when no such assignment exists.
+// A problem without variables is trivially satisfied.
+func (p *Problem[T]) Solve() (map[string]T, bool) {
+	s := newSolver(p)
+	if !s.search(0) {
+		return nil, false
+	}
+
+	solution := make(map[string]T, len(p.names))
+	for v, name := range p.names {
+		solution[name] = p.domains[v][s.assigned[v]]
+	}
+	return solution, true
+}
+
+// NotEqual is a constraint that requires both variables to take different values.
+func NotEqual[T comparable](x, y T) bool {
+	return x != y
+}
+
+// removal records a domain value pruned by forward checking so it can be restored.
+type removal struct {
+	variable int
+	value    int
+}
+
+// solver holds the mutable state of one backtracking search.
+type solver[T any] struct {
+	problem  *Problem[T]
+	live     [][]bool // live[v][i] reports whether domains[v][i] is still available
+	size     []int    // Number of live values per variable
+	assigned []int    // Index of the assigned value per variable, or -1
+}
+
+func newSolver[T any](p *Problem[T]) *solver[T] {
+	n := len(p.names)
+	s := &solver[T]{
+		problem:  p,
+		live:     make([][]bool, n),
+		size:     make([]int, n),
+		assigned: make([]int, n),
+	}
+	for v, domain := range p.domains {
+		s.live[v] = make([]bool, len(domain))
+		for i := range domain {
+			s.live[v][i] = true
+		}
+		s.size[v] = len(domain)
+		s.assigned[v] = -1
+	}
+	return s
+}
+
+func (s *solver[T]) search(depth int) bool {
+	if depth == len(s.assigned) {
+		return true
+	}
+
+	v := s.selectVariable()
+	for i, value := range s.problem.domains[v] {
+		if !s.live[v][i] {
+			continue
+		}
+
+		s.assigned[v] = i
+		pruned, ok := s.forwardCheck(v, value)
+		if ok && s.search(depth+1) {
+			return true
+		}
+		s.restore(pruned)
+	}
+
+	s.assigned[v] = -1
+	return false
+}
+
+// selectVariable picks the unassigned variable with the fewest live values,
+// breaking ties by the number of constraints and then by insertion order.
+func (s *solver[T]) selectVariable() int {
+	best := -1
+	for v := range s.assigned {
+		if s.assigned[v] >= 0 {
+			continue
+		}
+		if best < 0 || s.size[v] < s.size[best] ||
+			(s.size[v] == s.size[best] && len(s.problem.arcs[v]) > len(s.problem.arcs[best])) {
+			best = v
+		}
+	}
+	return best
+}
+
+// forwardCheck removes values of unassigned neighbours of v that are
+// inconsistent with value. It returns the removals and false if some
+// neighbour is left without values.
+func (s *solver[T]) forwardCheck(v int, value T) ([]removal, bool) {
+	var pruned []removal
+	for _, a := range s.problem.arcs[v] {
+		u := a.other
+		if s.assigned[u] >= 0 {
+			continue
+		}
+
+		for j, candidate := range s.problem.domains[u] {
+			if s.live[u][j] && !a.allowed(value, candidate) {
+				s.live[u][j] = false
+				s.size[u]--
+				pruned = append(pruned, removal{variable: u, value: j})
+			}
+		}
+		if s.size[u] == 0 {
+			return pruned, false
+		}
+	}
+	return pruned, true
+}
+
+func (s *solver[T]) restore(pruned []removal) {
+	for _, r := range pruned {
+		s.live[r.variable][r.value] = true
+		s.size[r.variable]++
+	}
+}
@@ -0,0 +1,97 @@
+package csp
+
+import "testing"
+
+func TestSolveAustraliaMapColoring(t *testing.T) {
+	colors := []string{"red", "green", "blue"}
+	p := NewProblem[string]()
+	for _, region := range []string{"WA", "NT", "SA", "Q", "NSW", "V", "T"} {
+		if !p.AddVariable(region, colors) {
+			t.Fatalf("expected AddVariable to accept %s", region)
+		}
+	}
+
+	borders := [][2]string{
+		{"WA", "NT"}, {"WA", "SA"}, {"NT", "SA"}, {"NT", "Q"},
+		{"SA", "Q"}, {"SA", "NSW"}, {"SA", "V"}, {"Q", "NSW"}, {"NSW", "V"},
+	}
+	for _, b := range borders {
+		if !p.AddConstraint(b[0], b[1], NotEqual[string]) {
+			t.Fatalf("expected AddConstraint to accept %v", b)
+		}
+	}
+
+	solution, ok := p.Solve()
+	if !ok {
+		t.Fatal("expected map coloring to be solvable with 3 colors")
+	}
+	if len(solution) != 7 {
+		t.Fatalf("expected 7 assigned regions, got %d", len(solution))
+	}
+	for _, b := range borders {
+		if solution[b[0]] == solution[b[1]] {
+			t.Fatalf("expected %s and %s to differ, got %v", b[0], b[1], solution)
+		}
+	}
+}
+
+func TestSolveUnsatisfiable(t *testing.T) {
+	p := NewProblem[int]()
+	for _, name := range []string{"A", "B", "C"} {
+		p.AddVariable(name, []int{0, 1})
+	}
+	p.AddConstraint("A", "B", NotEqual[int])
+	p.AddConstraint("B", "C", NotEqual[int])
+	p.AddConstraint("A", "C", NotEqual[int])
+
+	if solution, ok := p.Solve(); ok || solution != nil {
+		t.Fatalf("expected triangle to have no 2-coloring, got %v", solution)
+	}
+}
+
+func TestSolveAsymmetricConstraint(t *testing.T) {
+	p := NewProblem[int]()
+	p.AddVariable("X", []int{1, 2, 3})
+	p.AddVariable("Y", []int{1, 2, 3})
+	p.AddConstraint("X", "Y", func(x, y int) bool { return x > y })
+
+	solution, ok := p.Solve()
+	if !ok {
+		t.Fatal("expected X > Y to be satisfiable")
+	}
+	if solution["X"] <= solution["Y"] {
+		t.Fatalf("expected X > Y, got %v", solution)
+	}
+}
+
+func TestProblemInvalidInputs(t *testing.T) {
+	p := NewProblem[int]()
+
+	if p.AddVariable("", []int{1}) {
+		t.Error("expected AddVariable to reject empty name")
+	}
+	if !p.AddVariable("A", []int{1}) {
+		t.Error("expected AddVariable to accept A")
+	}
+	if p.AddVariable("A", []int{2}) {
+		t.Error("expected AddVariable to reject duplicate")
+	}
+	if p.AddConstraint("A", "Missing", NotEqual[int]) {
+		t.Error("expected AddConstraint to reject missing variable")
+	}
+	if p.AddConstraint("A", "A", NotEqual[int]) {
+		t.Error("expected AddConstraint to reject self constraint")
+	}
+	if p.AddConstraint("A", "A", nil) {
+		t.Error("expected AddConstraint to reject nil predicate")
+	}
+
+	p.AddVariable("Empty", nil)
+	if _, ok := p.Solve(); ok {
+		t.Error("expected Solve to fail when a domain is empty")
+	}
+
+	if solution, ok := NewProblem[int]().Solve(); !ok || len(solution) != 0 {
+		t.Error("expected empty problem to be trivially satisfied")
+	}
+}
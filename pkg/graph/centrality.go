@@ -0,0 +1,361 @@
+package graph
+
+import (
+	"container/heap"
+	"math"
+	"sync"
+)
+
+// PageRank computes the PageRank score of every vertex using power iteration.
+// Each vertex splits its score evenly across its outbound edges; vertices
+// without outbound edges spread their score across all vertices.
+// In undirected graphs every edge is followed in both directions.
+//
+// damping must be in (0, 1), tolerance and maxIterations must be positive.
+// Invalid values fall back to 0.85, 1e-6 and 100 respectively.
+// Iteration stops once the L1 change between two rounds drops below tolerance.
+// The returned scores sum to 1.
+func (g *Graph) PageRank(damping, tolerance float64, maxIterations int) map[string]float64 {
+	if damping <= 0 || damping >= 1 {
+		damping = 0.85
+	}
+	if tolerance <= 0 {
+		tolerance = 1e-6
+	}
+	if maxIterations <= 0 {
+		maxIterations = 100
+	}
+
+	ids := g.sortedVertexIDs()
+	n := len(ids)
+	scores := make(map[string]float64, n)
+	if n == 0 {
+		return scores
+	}
+
+	adj := g.indexedAdjacency(ids)
+	rank := make([]float64, n)
+	next := make([]float64, n)
+	for i := range rank {
+		rank[i] = 1 / float64(n)
+	}
+
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		dangling := 0.0
+		for i, edges := range adj {
+			if len(edges) == 0 {
+				dangling += rank[i]
+			}
+		}
+
+		base := (1-damping)/float64(n) + damping*dangling/float64(n)
+		for i := range next {
+			next[i] = base
+		}
+		for i, edges := range adj {
+			if len(edges) == 0 {
+				continue
+			}
+			share := damping * rank[i] / float64(len(edges))
+			for _, edge := range edges {
+				next[edge.to] += share
+			}
+		}
+
+		delta := 0.0
+		for i := range rank {
+			delta += math.Abs(next[i] - rank[i])
+		}
+		rank, next = next, rank
+		if delta < tolerance {
+			break
+		}
+	}
+
+	for i, id := range ids {
+		scores[id] = rank[i]
+	}
+	return scores
+}
+
+// BetweennessCentrality computes the betweenness centrality of every vertex
+// using Brandes' algorithm: the number of shortest paths between other pairs
+// of vertices that pass through it, split evenly between equally short paths.
+// In undirected graphs each pair is counted once.
+//
+// When weighted is true, path lengths use edge weights and the result is nil
+// if the graph contains negative-weight edges; otherwise every edge counts as 1.
+// When workers is greater than 1, source vertices are split across that many
+// goroutines. Results do not depend on the number of workers.
+func (g *Graph) BetweennessCentrality(weighted bool, workers int) map[string]float64 {
+	if weighted && g.hasNegativeWeightEdge() {
+		return nil
+	}
+
+	ids := g.sortedVertexIDs()
+	n := len(ids)
+	scores := make(map[string]float64, n)
+	if n == 0 {
+		return scores
+	}
+
+	adj := g.indexedAdjacency(ids)
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > n {
+		workers = n
+	}
+
+	partials := make([][]float64, workers)
+	run := func(worker int) {
+		state := newBrandesState(n)
+		for source := worker; source < n; source += workers {
+			state.accumulate(adj, source, weighted)
+		}
+		partials[worker] = state.centrality
+	}
+
+	if workers == 1 {
+		run(0)
+	} else {
+		var wg sync.WaitGroup
+		for worker := 0; worker < workers; worker++ {
+			wg.Add(1)
+			go func(worker int) {
+				defer wg.Done()
+				run(worker)
+			}(worker)
+		}
+		wg.Wait()
+	}
+
+	for i, id := range ids {
+		total := 0.0
+		for _, partial := range partials {
+			total += partial[i]
+		}
+		if !g.directed {
+			total /= 2
+		}
+		scores[id] = total
+	}
+	return scores
+}
+
+// ClosenessCentrality computes the closeness centrality of every vertex from
+// its outbound distances. A vertex that reaches r of the other n-1 vertices
+// with total distance d scores (r/d) * (r/(n-1)), so vertices in small
+// components are not overrated. Vertices that reach nothing score 0.
+//
+// When weighted is true, distances use edge weights and the result is nil if
+// the graph contains negative-weight edges; otherwise every edge counts as 1.
+func (g *Graph) ClosenessCentrality(weighted bool) map[string]float64 {
+	if weighted && g.hasNegativeWeightEdge() {
+		return nil
+	}
+
+	ids := g.sortedVertexIDs()
+	n := len(ids)
+	scores := make(map[string]float64, n)
+	if n == 0 {
+		return scores
+	}
+
+	adj := g.indexedAdjacency(ids)
+	for i, id := range ids {
+		dist := singleSourceDistances(adj, i, weighted)
+		// Summing in float64 keeps large distances from overflowing.
+		reachable, total := 0, 0.0
+		for j, d := range dist {
+			if j == i || d < 0 {
+				continue
+			}
+			reachable++
+			total += float64(d)
+		}
+
+		if reachable == 0 || total == 0 {
+			scores[id] = 0
+			continue
+		}
+		r := float64(reachable)
+		scores[id] = (r / total) * (r / float64(n-1))
+	}
+	return scores
+}
+
+// DegreeCentrality returns the degree of every vertex divided by n-1.
+// In directed graphs the total degree (in-degree + out-degree) is used,
+// so scores may exceed 1.
+func (g *Graph) DegreeCentrality() map[string]float64 {
+	scores := make(map[string]float64, len(g.vertices))
+	if len(g.vertices) <= 1 {
+		for id := range g.vertices {
+			scores[id] = 0
+		}
+		return scores
+	}
+
+	inDegree := make(map[string]int, len(g.vertices))
+	if g.directed {
+		for _, vertex := range g.vertices {
+			for neighborID := range vertex.edges {
+				inDegree[neighborID]++
+			}
+		}
+	}
+
+	scale := float64(len(g.vertices) - 1)
+	for id, vertex := range g.vertices {
+		scores[id] = float64(len(vertex.edges)+inDegree[id]) / scale
+	}
+	return scores
+}
+
+// brandesState holds the per-source scratch buffers of Brandes' algorithm so
+// they can be reused across sources.
+type brandesState struct {
+	order      []int
+	preds      [][]int
+	sigma      []float64
+	dist       []int
+	delta      []float64
+	centrality []float64
+}
+
+func newBrandesState(n int) *brandesState {
+	return &brandesState{
+		order:      make([]int, 0, n),
+		preds:      make([][]int, n),
+		sigma:      make([]float64, n),
+		dist:       make([]int, n),
+		delta:      make([]float64, n),
+		centrality: make([]float64, n),
+	}
+}
+
+// accumulate adds the dependencies of source on every other vertex to centrality.
+func (s *brandesState) accumulate(adj [][]indexedEdge, source int, weighted bool) {
+	s.order = s.order[:0]
+	for i := range s.dist {
+		s.preds[i] = s.preds[i][:0]
+		s.sigma[i] = 0
+		s.dist[i] = -1
+		s.delta[i] = 0
+	}
+	s.sigma[source] = 1
+	s.dist[source] = 0
+
+	if weighted {
+		s.dijkstra(adj, source)
+	} else {
+		s.bfs(adj, source)
+	}
+
+	for i := len(s.order) - 1; i >= 0; i-- {
+		w := s.order[i]
+		for _, v := range s.preds[w] {
+			s.delta[v] += s.sigma[v] / s.sigma[w] * (1 + s.delta[w])
+		}
+		if w != source {
+			s.centrality[w] += s.delta[w]
+		}
+	}
+}
+
+func (s *brandesState) bfs(adj [][]indexedEdge, source int) {
+	queue := []int{source}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		s.order = append(s.order, v)
+		for _, edge := range adj[v] {
+			w := edge.to
+			if s.dist[w] < 0 {
+				s.dist[w] = s.dist[v] + 1
+				queue = append(queue, w)
+			}
+			if s.dist[w] == s.dist[v]+1 {
+				s.sigma[w] += s.sigma[v]
+				s.preds[w] = append(s.preds[w], v)
+			}
+		}
+	}
+}
+
+// dijkstra computes distances from source first and counts shortest paths
+// afterwards, in a depth-first order of the tight edges, those on some
+// shortest path. Counting while settling vertices would miss predecessors
+// joined by zero-weight edges that happen to be settled later. On a cycle of
+// zero-weight edges the edge closing the cycle is not counted.
+func (s *brandesState) dijkstra(adj [][]indexedEdge, source int) {
+	settled := make([]bool, len(adj))
+	pq := &indexQueue{{vertex: source, priority: 0}}
+	for pq.Len() > 0 {
+		current := heap.Pop(pq).(indexItem)
+		v := current.vertex
+		if settled[v] || current.priority > s.dist[v] {
+			continue
+		}
+		settled[v] = true
+		for _, edge := range adj[v] {
+			tentative, ok := addCost(s.dist[v], edge.weight)
+			if ok && !settled[edge.to] && (s.dist[edge.to] < 0 || tentative < s.dist[edge.to]) {
+				s.dist[edge.to] = tentative
+				heap.Push(pq, indexItem{vertex: edge.to, priority: tentative})
+			}
+		}
+	}
+
+	s.tightOrder(adj, source)
+	rank := make([]int, len(adj))
+	for i, v := range s.order {
+		rank[v] = i
+	}
+	for _, v := range s.order {
+		for _, edge := range adj[v] {
+			if w := edge.to; s.tight(v, edge) && rank[v] < rank[w] {
+				s.sigma[w] += s.sigma[v]
+				s.preds[w] = append(s.preds[w], v)
+			}
+		}
+	}
+}
+
+// tight reports whether edge, leaving v, lies on a shortest path.
+func (s *brandesState) tight(v int, edge indexedEdge) bool {
+	if s.dist[edge.to] < 0 {
+		return false
+	}
+	total, ok := addCost(s.dist[v], edge.weight)
+	return ok && total == s.dist[edge.to]
+}
+
+// tightOrder fills s.order with the vertices source reaches, in reverse
+// postorder of a depth-first search over tight edges. Every tight edge points
+// forward in that order except one closing a cycle, which must consist of
+// zero-weight edges.
+func (s *brandesState) tightOrder(adj [][]indexedEdge, source int) {
+	type frame struct{ vertex, next int }
+	visited := make([]bool, len(adj))
+	visited[source] = true
+	stack := []frame{{vertex: source}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.next == len(adj[top.vertex]) {
+			s.order = append(s.order, top.vertex)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		edge := adj[top.vertex][top.next]
+		top.next++
+		if !visited[edge.to] && s.tight(top.vertex, edge) {
+			visited[edge.to] = true
+			stack = append(stack, frame{vertex: edge.to})
+		}
+	}
+	for i, j := 0, len(s.order)-1; i < j; i, j = i+1, j-1 {
+		s.order[i], s.order[j] = s.order[j], s.order[i]
+	}
+}
@@ -0,0 +1,172 @@
+package graph
+
+import (
+	"math"
+	"testing"
+)
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestPageRank(t *testing.T) {
+	g := NewGraph(true)
+	g.AddEdge("A", "B", 1)
+	g.AddEdge("B", "C", 1)
+	g.AddEdge("C", "A", 1)
+
+	scores := g.PageRank(0.85, 1e-12, 1000)
+	for _, id := range []string{"A", "B", "C"} {
+		if math.Abs(scores[id]-1.0/3) > 1e-6 {
+			t.Fatalf("expected symmetric cycle rank 1/3 for %s, got %f", id, scores[id])
+		}
+	}
+
+	star := NewGraph(true)
+	star.AddEdge("A", "Hub", 1)
+	star.AddEdge("B", "Hub", 1)
+	star.AddEdge("C", "Hub", 1)
+
+	scores = star.PageRank(0, 0, 0)
+	sum := 0.0
+	for _, score := range scores {
+		sum += score
+	}
+	if math.Abs(sum-1) > 1e-6 {
+		t.Fatalf("expected scores to sum to 1, got %f", sum)
+	}
+	if scores["Hub"] <= scores["A"] {
+		t.Fatalf("expected Hub to outrank A, got %v", scores)
+	}
+
+	if len(NewGraph(true).PageRank(0.85, 1e-6, 100)) != 0 {
+		t.Fatal("expected empty result for empty graph")
+	}
+}
+
+func TestBetweennessCentrality(t *testing.T) {
+	t.Run("undirected_path", func(t *testing.T) {
+		g := NewGraph(false)
+		g.AddEdge("A", "B", 1)
+		g.AddEdge("B", "C", 1)
+		g.AddEdge("C", "D", 1)
+
+		scores := g.BetweennessCentrality(false, 1)
+		expected := map[string]float64{"A": 0, "B": 2, "C": 2, "D": 0}
+		for id, want := range expected {
+			if !almostEqual(scores[id], want) {
+				t.Fatalf("expected betweenness %f for %s, got %f", want, id, scores[id])
+			}
+		}
+	})
+
+	t.Run("weighted_detour", func(t *testing.T) {
+		g := NewGraph(true)
+		g.AddEdge("A", "C", 10)
+		g.AddEdge("A", "B", 1)
+		g.AddEdge("B", "C", 1)
+
+		if got := g.BetweennessCentrality(false, 1)["B"]; got != 0 {
+			t.Fatalf("expected unweighted betweenness 0 for B, got %f", got)
+		}
+		if got := g.BetweennessCentrality(true, 1)["B"]; got != 1 {
+			t.Fatalf("expected weighted betweenness 1 for B, got %f", got)
+		}
+	})
+
+	t.Run("split_between_equal_paths", func(t *testing.T) {
+		g := NewGraph(false)
+		g.AddEdge("A", "B", 1)
+		g.AddEdge("A", "C", 1)
+		g.AddEdge("B", "D", 1)
+		g.AddEdge("C", "D", 1)
+
+		scores := g.BetweennessCentrality(true, 1)
+		if !almostEqual(scores["B"], 0.5) || !almostEqual(scores["C"], 0.5) {
+			t.Fatalf("expected B and C to share betweenness 0.5, got %v", scores)
+		}
+	})
+
+	t.Run("zero_weight_predecessor", func(t *testing.T) {
+		// X sorts before Y, so X is settled first even though Y, at the same
+		// distance, reaches it over a zero-weight edge.
+		g := NewGraph(true)
+		g.AddEdge("S", "X", 1)
+		g.AddEdge("S", "Y", 1)
+		g.AddEdge("Y", "X", 0)
+		g.AddEdge("X", "T", 1)
+
+		scores := g.BetweennessCentrality(true, 1)
+		if !almostEqual(scores["Y"], 1) || !almostEqual(scores["X"], 2) {
+			t.Fatalf("expected Y to score 1 and X 2, got %v", scores)
+		}
+	})
+
+	t.Run("zero_weight_cycle", func(t *testing.T) {
+		g := NewGraph(false)
+		g.AddEdge("A", "B", 0)
+		g.AddEdge("B", "C", 1)
+		scores := g.BetweennessCentrality(true, 1)
+		if !almostEqual(scores["B"], 1) {
+			t.Fatalf("expected B to lie on the A-C path, got %v", scores)
+		}
+	})
+
+	t.Run("parallel_matches_sequential", func(t *testing.T) {
+		g := BuildRomaniaGraph()
+		sequential := g.BetweennessCentrality(true, 1)
+		parallel := g.BetweennessCentrality(true, 4)
+		for id, want := range sequential {
+			if parallel[id] != want {
+				t.Fatalf("expected parallel score %f for %s, got %f", want, id, parallel[id])
+			}
+		}
+	})
+
+	t.Run("negative_weight", func(t *testing.T) {
+		g := NewGraph(true)
+		g.AddEdge("A", "B", -1)
+		if g.BetweennessCentrality(true, 1) != nil {
+			t.Fatal("expected nil result for negative weights")
+		}
+	})
+}
+
+func TestClosenessCentrality(t *testing.T) {
+	g := NewGraph(false)
+	g.AddEdge("A", "B", 1)
+	g.AddEdge("B", "C", 3)
+
+	unweighted := g.ClosenessCentrality(false)
+	if !almostEqual(unweighted["B"], 1) {
+		t.Fatalf("expected closeness 1 for B, got %f", unweighted["B"])
+	}
+	if !almostEqual(unweighted["A"], 2.0/3) {
+		t.Fatalf("expected closeness 2/3 for A, got %f", unweighted["A"])
+	}
+
+	weighted := g.ClosenessCentrality(true)
+	if !almostEqual(weighted["B"], 0.5) {
+		t.Fatalf("expected weighted closeness 0.5 for B, got %f", weighted["B"])
+	}
+
+	g.AddVertex("Isolated")
+	if got := g.ClosenessCentrality(false)["Isolated"]; got != 0 {
+		t.Fatalf("expected closeness 0 for isolated vertex, got %f", got)
+	}
+}
+
+func TestDegreeCentrality(t *testing.T) {
+	g := NewGraph(true)
+	g.AddEdge("A", "B", 1)
+	g.AddEdge("C", "B", 1)
+	g.AddEdge("B", "D", 1)
+
+	scores := g.DegreeCentrality()
+	if !almostEqual(scores["B"], 1) {
+		t.Fatalf("expected degree centrality 1 for B, got %f", scores["B"])
+	}
+	if !almostEqual(scores["A"], 1.0/3) {
+		t.Fatalf("expected degree centrality 1/3 for A, got %f", scores["A"])
+	}
+}
@@ -0,0 +1,211 @@
+package graph
+
+import (
+	"sort"
+
+	"github.com/JeanGrijp/go-datastructures/pkg/csp"
+)
+
+// GreedyColoring colors vertices in order of decreasing degree (Welsh-Powell),
+// giving each vertex the smallest color not used by its neighbors.
+// Colors start at 0. It returns the coloring and the number of colors used.
+// Edge direction is ignored.
+func (g *Graph) GreedyColoring() (map[string]int, int) {
+	ids := g.sortedVertexIDs()
+	neighbors := g.undirectedNeighbors(ids)
+
+	order := make([]int, len(ids))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return len(neighbors[order[a]]) > len(neighbors[order[b]])
+	})
+
+	colors := make([]int, len(ids))
+	for i := range colors {
+		colors[i] = -1
+	}
+	for _, v := range order {
+		colors[v] = smallestFreeColor(neighbors[v], colors)
+	}
+
+	return colorMap(ids, colors)
+}
+
+// DSaturColoring colors vertices with Brélaz's DSatur heuristic: the next
+// vertex is the uncolored one with the most distinct neighbor colors, ties
+// broken by degree and then by ID. Colors start at 0. It returns the coloring
+// and the number of colors used. Edge direction is ignored.
+func (g *Graph) DSaturColoring() (map[string]int, int) {
+	ids := g.sortedVertexIDs()
+	neighbors := g.undirectedNeighbors(ids)
+
+	colors := make([]int, len(ids))
+	for i := range colors {
+		colors[i] = -1
+	}
+	saturation := make([]map[int]struct{}, len(ids))
+	for i := range saturation {
+		saturation[i] = make(map[int]struct{})
+	}
+
+	for colored := 0; colored < len(ids); colored++ {
+		best := -1
+		for v := range ids {
+			if colors[v] >= 0 {
+				continue
+			}
+			if best < 0 || len(saturation[v]) > len(saturation[best]) ||
+				(len(saturation[v]) == len(saturation[best]) && len(neighbors[v]) > len(neighbors[best])) {
+				best = v
+			}
+		}
+
+		colors[best] = smallestFreeColor(neighbors[best], colors)
+		for _, u := range neighbors[best] {
+			saturation[u][colors[best]] = struct{}{}
+		}
+	}
+
+	return colorMap(ids, colors)
+}
+
+// KColoring searches for a proper coloring that uses at most k colors,
+// numbered 0 to k-1. It is exact: when it returns (nil, false) no k-coloring
+// exists. The search is solved as a constraint satisfaction problem with
+// the csp package. Edge direction is ignored.
+func (g *Graph) KColoring(k int) (map[string]int, bool) {
+	if k < 0 {
+		return nil, false
+	}
+	if len(g.vertices) == 0 {
+		return map[string]int{}, true
+	}
+	if k == 0 {
+		return nil, false
+	}
+
+	ids := g.sortedVertexIDs()
+	neighbors := g.undirectedNeighbors(ids)
+
+	palette := make([]int, k)
+	for i := range palette {
+		palette[i] = i
+	}
+
+	// Colors are interchangeable, so the vertex with the highest degree
+	// can be fixed to color 0 without losing solutions.
+	anchor := 0
+	for v := range ids {
+		if len(neighbors[v]) > len(neighbors[anchor]) {
+			anchor = v
+		}
+	}
+
+	problem := csp.NewProblem[int]()
+	for v, id := range ids {
+		if v == anchor {
+			problem.AddVariable(id, palette[:1])
+		} else {
+			problem.AddVariable(id, palette)
+		}
+	}
+	for v, id := range ids {
+		for _, u := range neighbors[v] {
+			if v < u {
+				problem.AddConstraint(id, ids[u], csp.NotEqual[int])
+			}
+		}
+	}
+
+	return problem.Solve()
+}
+
+// ChromaticNumber returns the minimum number of colors needed to properly
+// color the graph, together with a coloring that achieves it.
+// DSatur provides an upper bound that KColoring then tries to improve.
+// The running time is exponential in the worst case.
+func (g *Graph) ChromaticNumber() (int, map[string]int) {
+	upperColors, upper := g.DSaturColoring()
+	if upper <= 2 {
+		return upper, upperColors
+	}
+
+	for k := 2; k < upper; k++ {
+		if colors, ok := g.KColoring(k); ok {
+			return k, colors
+		}
+	}
+	return upper, upperColors
+}
+
+// IsProperColoring reports whether colors assigns a color to every vertex
+// and no two adjacent vertices share a color.
+func (g *Graph) IsProperColoring(colors map[string]int) bool {
+	for id, vertex := range g.vertices {
+		color, ok := colors[id]
+		if !ok {
+			return false
+		}
+		for neighborID := range vertex.edges {
+			if other, ok := colors[neighborID]; ok && other == color {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// undirectedNeighbors returns, for every vertex index, the sorted indices of
+// vertices connected to it by an edge in either direction.
+func (g *Graph) undirectedNeighbors(ids []string) [][]int {
+	adj := g.indexedAdjacency(ids)
+	sets := make([]map[int]struct{}, len(ids))
+	for i := range sets {
+		sets[i] = make(map[int]struct{})
+	}
+	for v, edges := range adj {
+		for _, edge := range edges {
+			sets[v][edge.to] = struct{}{}
+			sets[edge.to][v] = struct{}{}
+		}
+	}
+
+	neighbors := make([][]int, len(ids))
+	for v, set := range sets {
+		for u := range set {
+			neighbors[v] = append(neighbors[v], u)
+		}
+		sort.Ints(neighbors[v])
+	}
+	return neighbors
+}
+
+func smallestFreeColor(neighbors []int, colors []int) int {
+	used := make(map[int]struct{}, len(neighbors))
+	for _, u := range neighbors {
+		if colors[u] >= 0 {
+			used[colors[u]] = struct{}{}
+		}
+	}
+	color := 0
+	for {
+		if _, ok := used[color]; !ok {
+			return color
+		}
+		color++
+	}
+}
+
+func colorMap(ids []string, colors []int) (map[string]int, int) {
+	result := make(map[string]int, len(ids))
+	count := 0
+	for v, id := range ids {
+		result[id] = colors[v]
+		if colors[v]+1 > count {
+			count = colors[v] + 1
+		}
+	}
+	return result, count
+}
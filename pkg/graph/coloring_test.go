@@ -0,0 +1,98 @@
+package graph
+
+import "testing"
+
+func buildCycleGraph(n int) *Graph {
+	g := NewGraph(false)
+	ids := []string{"A", "B", "C", "D", "E", "F", "G", "H"}[:n]
+	for i := range ids {
+		g.AddEdge(ids[i], ids[(i+1)%n], 1)
+	}
+	return g
+}
+
+func buildCompleteGraph(ids ...string) *Graph {
+	g := NewGraph(false)
+	for _, a := range ids {
+		for _, b := range ids {
+			g.AddEdge(a, b, 1)
+		}
+	}
+	return g
+}
+
+func TestGreedyAndDSaturColoring(t *testing.T) {
+	g := BuildRomaniaGraph()
+
+	greedy, greedyCount := g.GreedyColoring()
+	if !g.IsProperColoring(greedy) {
+		t.Fatalf("expected greedy coloring to be proper, got %v", greedy)
+	}
+
+	dsatur, dsaturCount := g.DSaturColoring()
+	if !g.IsProperColoring(dsatur) {
+		t.Fatalf("expected DSatur coloring to be proper, got %v", dsatur)
+	}
+	if dsaturCount != 3 {
+		t.Fatalf("expected DSatur to use 3 colors on Romania, got %d", dsaturCount)
+	}
+	if greedyCount < dsaturCount {
+		t.Fatalf("expected greedy count %d to be at least the chromatic bound", greedyCount)
+	}
+
+	// An even cycle is bipartite; DSatur is exact on bipartite graphs.
+	if _, count := buildCycleGraph(6).DSaturColoring(); count != 2 {
+		t.Fatalf("expected even cycle to use 2 colors, got %d", count)
+	}
+}
+
+func TestKColoring(t *testing.T) {
+	oddCycle := buildCycleGraph(5)
+	if colors, ok := oddCycle.KColoring(2); ok {
+		t.Fatalf("expected odd cycle to have no 2-coloring, got %v", colors)
+	}
+
+	colors, ok := oddCycle.KColoring(3)
+	if !ok {
+		t.Fatal("expected odd cycle to be 3-colorable")
+	}
+	if !oddCycle.IsProperColoring(colors) {
+		t.Fatalf("expected proper coloring, got %v", colors)
+	}
+
+	directed := NewGraph(true)
+	directed.AddEdge("A", "B", 1)
+	directed.AddEdge("B", "A", 1)
+	if _, ok := directed.KColoring(1); ok {
+		t.Fatal("expected adjacent vertices to need two colors")
+	}
+
+	if colors, ok := NewGraph(false).KColoring(0); !ok || len(colors) != 0 {
+		t.Fatal("expected empty graph to be 0-colorable")
+	}
+}
+
+func TestChromaticNumber(t *testing.T) {
+	cases := []struct {
+		name     string
+		graph    *Graph
+		expected int
+	}{
+		{name: "romania", graph: BuildRomaniaGraph(), expected: 3},
+		{name: "even_cycle", graph: buildCycleGraph(8), expected: 2},
+		{name: "odd_cycle", graph: buildCycleGraph(7), expected: 3},
+		{name: "complete_4", graph: buildCompleteGraph("A", "B", "C", "D"), expected: 4},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			k, colors := tc.graph.ChromaticNumber()
+			if k != tc.expected {
+				t.Fatalf("expected chromatic number %d, got %d", tc.expected, k)
+			}
+			if !tc.graph.IsProperColoring(colors) {
+				t.Fatalf("expected proper coloring, got %v", colors)
+			}
+		})
+	}
+}
@@ -0,0 +1,342 @@
+package graph
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// Community detection treats the graph as undirected: the weight between two
+// vertices is the sum of the weights of the edges joining them in either
+// direction. Communities are numbered 0, 1, 2, ... in the order their first
+// member appears among the vertex IDs sorted ascending, so equal partitions
+// always get equal numbers.
+
+// Louvain partitions the graph into communities by greedily optimizing
+// modularity with the Louvain method. Each level moves single vertices to the
+// neighbouring community with the best modularity gain until no move helps,
+// then merges every community into one vertex and repeats on the smaller
+// graph. Vertices are visited in ID order, so the result is deterministic.
+//
+// resolution scales the penalty for large communities: values above 1 give
+// more and smaller communities, values below 1 fewer and larger ones. Values
+// <= 0 use 1, the classic definition.
+//
+// It returns the community of every vertex and the partition's modularity
+// (at resolution 1), or (nil, 0) if the graph has negative-weight edges.
+func (g *Graph) Louvain(resolution float64) (map[string]int, float64) {
+	if resolution <= 0 {
+		resolution = 1
+	}
+
+	ids, base, ok := g.communityGraph()
+	if !ok {
+		return nil, 0
+	}
+
+	level := base
+	membership := make([]int, len(ids))
+	for i := range membership {
+		membership[i] = i
+	}
+	for level.total > 0 {
+		communities, moved := level.localMoves(resolution)
+		if !moved {
+			break
+		}
+		for i, c := range membership {
+			membership[i] = communities[c]
+		}
+		level = level.aggregate(communities)
+	}
+
+	return communityResult(ids, base, membership)
+}
+
+// LabelPropagation partitions the graph with asynchronous label propagation.
+// Every vertex starts with its own label and repeatedly adopts the label with
+// the largest total edge weight among its neighbours, keeping its current
+// label when that is among the best. Vertices are visited in a random order
+// each round and remaining ties are broken randomly, both driven by seed, so
+// equal seeds give equal partitions. It stops when a round changes nothing or
+// after maxIterations rounds; maxIterations <= 0 uses 100.
+//
+// It returns the community of every vertex and the partition's modularity,
+// or (nil, 0) if the graph has negative-weight edges.
+func (g *Graph) LabelPropagation(seed int64, maxIterations int) (map[string]int, float64) {
+	if maxIterations <= 0 {
+		maxIterations = 100
+	}
+
+	ids, level, ok := g.communityGraph()
+	if !ok {
+		return nil, 0
+	}
+
+	r := rand.New(rand.NewSource(seed))
+	labels := make([]int, len(ids))
+	order := make([]int, len(ids))
+	for i := range labels {
+		labels[i] = i
+		order[i] = i
+	}
+
+	weights := make(map[int]float64)
+	var best []int
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		r.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+
+		changed := false
+		for _, u := range order {
+			if len(level.adj[u]) == 0 {
+				continue
+			}
+
+			clear(weights)
+			for v, w := range level.adj[u] {
+				weights[labels[v]] += w
+			}
+
+			best = best[:0]
+			bestWeight := 0.0
+			for label, w := range weights {
+				switch {
+				case len(best) == 0 || w > bestWeight:
+					best = append(best[:0], label)
+					bestWeight = w
+				case w == bestWeight:
+					best = append(best, label)
+				}
+			}
+
+			if weights[labels[u]] == bestWeight {
+				continue
+			}
+			// Map iteration order is random, so sort before the seeded pick.
+			sort.Ints(best)
+			labels[u] = best[r.Intn(len(best))]
+			changed = true
+		}
+		if !changed {
+			break
+		}
+	}
+
+	return communityResult(ids, level, labels)
+}
+
+// Modularity returns the modularity of a partition of the graph: the
+// fraction of edge weight inside communities minus the fraction expected if
+// edges were placed at random with the same vertex degrees. It ranges from
+// -1/2 to 1; higher means denser communities. Vertices missing from
+// communities count as communities of their own. It returns 0 for graphs
+// without edges or with negative-weight edges.
+func (g *Graph) Modularity(communities map[string]int) float64 {
+	ids, level, ok := g.communityGraph()
+	if !ok {
+		return 0
+	}
+
+	labels := make(map[int]int)
+	membership := make([]int, len(ids))
+	for i, id := range ids {
+		c, ok := communities[id]
+		if !ok {
+			membership[i] = len(ids) + i
+			continue
+		}
+		if _, seen := labels[c]; !seen {
+			labels[c] = len(labels)
+		}
+		membership[i] = labels[c]
+	}
+	return level.modularity(membership, 1)
+}
+
+// communityResult numbers communities canonically and pairs them with the
+// partition's modularity.
+func communityResult(ids []string, w *weightedGraph, membership []int) (map[string]int, float64) {
+	canonical := make(map[int]int)
+	communities := make(map[string]int, len(ids))
+	for i, id := range ids {
+		c, ok := canonical[membership[i]]
+		if !ok {
+			c = len(canonical)
+			canonical[membership[i]] = c
+		}
+		communities[id] = c
+		membership[i] = c
+	}
+	return communities, w.modularity(membership, 1)
+}
+
+// weightedGraph is a symmetric weighted graph used by community detection.
+// adj[u][v] is the weight between distinct vertices u and v and loops[u] the
+// weight of edges inside u, which appear once vertices are merged.
+type weightedGraph struct {
+	adj    []map[int]float64
+	loops  []float64
+	degree []float64
+	total  float64 // sum of all edge weights, each edge counted once
+}
+
+// communityGraph returns the sorted vertex IDs and the undirected weighted
+// view of the graph, or false if any weight is negative.
+func (g *Graph) communityGraph() ([]string, *weightedGraph, bool) {
+	if g.hasNegativeWeightEdge() {
+		return nil, nil, false
+	}
+
+	ids := g.sortedVertexIDs()
+	w := newWeightedGraph(len(ids))
+	for u, list := range g.indexedAdjacency(ids) {
+		for _, edge := range list {
+			weight := float64(edge.weight)
+			if !g.directed {
+				// Both directions are stored; count each edge once.
+				weight /= 2
+			}
+			w.adj[u][edge.to] += weight
+			w.adj[edge.to][u] += weight
+		}
+	}
+	w.computeDegrees()
+	return ids, w, true
+}
+
+func newWeightedGraph(n int) *weightedGraph {
+	w := &weightedGraph{
+		adj:    make([]map[int]float64, n),
+		loops:  make([]float64, n),
+		degree: make([]float64, n),
+	}
+	for i := range w.adj {
+		w.adj[i] = make(map[int]float64)
+	}
+	return w
+}
+
+// computeDegrees sets every vertex's weighted degree, in which loops count
+// twice, and the total edge weight, which is half the sum of the degrees.
+func (w *weightedGraph) computeDegrees() {
+	w.total = 0
+	for u, neighbors := range w.adj {
+		w.degree[u] = 2 * w.loops[u]
+		for _, weight := range neighbors {
+			w.degree[u] += weight
+		}
+		w.total += w.degree[u] / 2
+	}
+}
+
+// localMoves runs the first Louvain phase: vertices move to the neighbouring
+// community with the highest modularity gain until a full pass moves none.
+// It returns each vertex's community, numbered 0..k-1, and whether any vertex
+// moved.
+func (w *weightedGraph) localMoves(resolution float64) ([]int, bool) {
+	n := len(w.adj)
+	community := make([]int, n)
+	totals := make([]float64, n) // sum of degrees in each community
+	for u := range community {
+		community[u] = u
+		totals[u] = w.degree[u]
+	}
+
+	links := make(map[int]float64)
+	var candidates []int
+	moved := false
+	for improved := true; improved; {
+		improved = false
+		for u := 0; u < n; u++ {
+			current := community[u]
+			clear(links)
+			candidates = candidates[:0]
+			for v, weight := range w.adj[u] {
+				c := community[v]
+				if _, ok := links[c]; !ok {
+					candidates = append(candidates, c)
+				}
+				links[c] += weight
+			}
+			sort.Ints(candidates)
+
+			// Take u out of its community, then put it where the gain
+			// links[c] - resolution * totals[c] * degree[u] / 2m is highest.
+			totals[current] -= w.degree[u]
+			scale := resolution * w.degree[u] / (2 * w.total)
+			best := current
+			bestGain := links[current] - totals[current]*scale
+			for _, c := range candidates {
+				if gain := links[c] - totals[c]*scale; gain > bestGain+1e-12 {
+					best, bestGain = c, gain
+				}
+			}
+			totals[best] += w.degree[u]
+
+			if best != current {
+				community[u] = best
+				improved = true
+				moved = true
+			}
+		}
+	}
+
+	renumber := make(map[int]int)
+	for u, c := range community {
+		if _, ok := renumber[c]; !ok {
+			renumber[c] = len(renumber)
+		}
+		community[u] = renumber[c]
+	}
+	return community, moved
+}
+
+// aggregate merges every community into a single vertex.
+func (w *weightedGraph) aggregate(community []int) *weightedGraph {
+	size := 0
+	for _, c := range community {
+		size = max(size, c+1)
+	}
+
+	next := newWeightedGraph(size)
+	for u, neighbors := range w.adj {
+		cu := community[u]
+		next.loops[cu] += w.loops[u]
+		for v, weight := range neighbors {
+			if cv := community[v]; cv == cu {
+				// Visited from both ends, so each end adds half.
+				next.loops[cu] += weight / 2
+			} else {
+				next.adj[cu][cv] += weight
+			}
+		}
+	}
+	next.computeDegrees()
+	return next
+}
+
+// modularity returns the modularity of membership at the given resolution.
+func (w *weightedGraph) modularity(membership []int, resolution float64) float64 {
+	if w.total == 0 {
+		return 0
+	}
+
+	inside := make(map[int]float64)
+	totals := make(map[int]float64)
+	for u, neighbors := range w.adj {
+		c := membership[u]
+		inside[c] += w.loops[u]
+		totals[c] += w.degree[u]
+		for v, weight := range neighbors {
+			if membership[v] == c {
+				inside[c] += weight / 2
+			}
+		}
+	}
+
+	q := 0.0
+	for c, in := range inside {
+		share := totals[c] / (2 * w.total)
+		q += in/w.total - resolution*share*share
+	}
+	return q
+}
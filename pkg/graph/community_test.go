@@ -0,0 +1,153 @@
+package graph
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+// karateClubGraph builds Zachary's karate club network with vertices 1..34.
+func karateClubGraph() *Graph {
+	edges := [][2]int{
+		{1, 2}, {1, 3}, {1, 4}, {1, 5}, {1, 6}, {1, 7}, {1, 8}, {1, 9}, {1, 11}, {1, 12},
+		{1, 13}, {1, 14}, {1, 18}, {1, 20}, {1, 22}, {1, 32}, {2, 3}, {2, 4}, {2, 8}, {2, 14},
+		{2, 18}, {2, 20}, {2, 22}, {2, 31}, {3, 4}, {3, 8}, {3, 9}, {3, 10}, {3, 14}, {3, 28},
+		{3, 29}, {3, 33}, {4, 8}, {4, 13}, {4, 14}, {5, 7}, {5, 11}, {6, 7}, {6, 11}, {6, 17},
+		{7, 17}, {9, 31}, {9, 33}, {9, 34}, {10, 34}, {14, 34}, {15, 33}, {15, 34}, {16, 33}, {16, 34},
+		{19, 33}, {19, 34}, {20, 34}, {21, 33}, {21, 34}, {23, 33}, {23, 34}, {24, 26}, {24, 28}, {24, 30},
+		{24, 33}, {24, 34}, {25, 26}, {25, 28}, {25, 32}, {26, 32}, {27, 30}, {27, 34}, {28, 34}, {29, 32},
+		{29, 34}, {30, 33}, {30, 34}, {31, 33}, {31, 34}, {32, 33}, {32, 34}, {33, 34},
+	}
+	g := NewGraph(false)
+	for _, e := range edges {
+		g.AddEdge(strconv.Itoa(e[0]), strconv.Itoa(e[1]), 1)
+	}
+	return g
+}
+
+// twoCliques builds two 5-cliques joined by a single bridge edge.
+func twoCliques() *Graph {
+	g := NewGraph(false)
+	for _, prefix := range []string{"a", "b"} {
+		for i := 0; i < 5; i++ {
+			for j := i + 1; j < 5; j++ {
+				g.AddEdge(prefix+strconv.Itoa(i), prefix+strconv.Itoa(j), 1)
+			}
+		}
+	}
+	g.AddEdge("a0", "b0", 1)
+	return g
+}
+
+func TestModularity(t *testing.T) {
+	g := twoCliques()
+	split := map[string]int{}
+	for _, v := range g.GetVertices() {
+		split[v.ID()] = int(v.ID()[0])
+	}
+	// 20 of 21 edges are inside; each side has degree sum 21.
+	want := 20.0/21 - 2*0.25
+	if q := g.Modularity(split); math.Abs(q-want) > 1e-9 {
+		t.Fatalf("expected modularity %.4f, got %.4f", want, q)
+	}
+	if q := g.Modularity(map[string]int{}); q >= 0 {
+		t.Fatalf("expected singletons to have negative modularity, got %.4f", q)
+	}
+	if q := NewGraph(false).Modularity(nil); q != 0 {
+		t.Fatal("expected modularity 0 without edges")
+	}
+}
+
+func TestLouvain(t *testing.T) {
+	g := twoCliques()
+	communities, q := g.Louvain(1)
+	if communities["a0"] != 0 || communities["b0"] != 1 {
+		t.Fatalf("expected canonical numbering, got %v", communities)
+	}
+	for id, c := range communities {
+		if c != int(id[0]-'a') {
+			t.Fatalf("expected each clique to be one community, got %v", communities)
+		}
+	}
+	if math.Abs(q-g.Modularity(communities)) > 1e-9 {
+		t.Fatal("expected reported modularity to match Modularity")
+	}
+
+	karate := karateClubGraph()
+	communities, q = karate.Louvain(0)
+	if q < 0.41 || q > 0.42 {
+		t.Fatalf("expected karate club modularity near 0.419, got %.4f", q)
+	}
+	again, _ := karate.Louvain(0)
+	for id, c := range communities {
+		if again[id] != c {
+			t.Fatal("expected Louvain to be deterministic")
+		}
+	}
+
+	coarse, _ := karate.Louvain(0.2)
+	fine, _ := karate.Louvain(3)
+	if countCommunities(coarse) >= countCommunities(fine) {
+		t.Fatal("expected higher resolution to give more communities")
+	}
+
+	negative := NewGraph(false)
+	negative.AddEdge("A", "B", -1)
+	if communities, _ := negative.Louvain(1); communities != nil {
+		t.Fatal("expected negative weights to be rejected")
+	}
+}
+
+func TestLouvainWeightsAndDirection(t *testing.T) {
+	// A heavy edge decides which side the middle vertex joins.
+	g := NewGraph(true)
+	g.AddEdge("A", "B", 10)
+	g.AddEdge("B", "A", 10)
+	g.AddEdge("C", "D", 10)
+	g.AddEdge("B", "C", 1)
+	g.AddEdge("M", "A", 5)
+	g.AddEdge("M", "D", 1)
+
+	communities, _ := g.Louvain(1)
+	if communities["M"] != communities["A"] || communities["A"] == communities["C"] {
+		t.Fatalf("expected M with A and a separate C-D community, got %v", communities)
+	}
+
+	isolated := NewGraph(false)
+	isolated.AddVertex("X")
+	isolated.AddVertex("Y")
+	if communities, q := isolated.Louvain(1); len(communities) != 2 || communities["Y"] != 1 || q != 0 {
+		t.Fatal("expected isolated vertices in their own communities")
+	}
+}
+
+func TestLabelPropagation(t *testing.T) {
+	g := twoCliques()
+	communities, q := g.LabelPropagation(1, 0)
+	if countCommunities(communities) != 2 || communities["a1"] != communities["a4"] || communities["a1"] == communities["b1"] {
+		t.Fatalf("expected the two cliques, got %v", communities)
+	}
+	if math.Abs(q-g.Modularity(communities)) > 1e-9 {
+		t.Fatal("expected reported modularity to match Modularity")
+	}
+
+	karate := karateClubGraph()
+	first, q := karate.LabelPropagation(7, 0)
+	second, _ := karate.LabelPropagation(7, 0)
+	for id, c := range first {
+		if second[id] != c {
+			t.Fatal("expected equal seeds to give equal partitions")
+		}
+	}
+	if q <= 0 {
+		t.Fatalf("expected positive modularity, got %.4f", q)
+	}
+}
+
+func countCommunities(communities map[string]int) int {
+	seen := make(map[int]struct{})
+	for _, c := range communities {
+		seen[c] = struct{}{}
+	}
+	return len(seen)
+}
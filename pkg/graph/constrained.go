@@ -0,0 +1,208 @@
+package graph
+
+import "container/heap"
+
+// PathOptions constrains the routes ShortestPathWith may return. The zero
+// value imposes no constraints.
+type PathOptions struct {
+	// ExcludeVertices lists vertices the route must not visit.
+	ExcludeVertices []string
+	// ExcludeEdges lists edges, as {from, to} pairs, the route must not use.
+	// In an undirected graph an excluded edge is excluded in both directions.
+	ExcludeEdges [][2]string
+	// Waypoints lists vertices the route must visit, in this order, between
+	// start and goal.
+	Waypoints []string
+	// MaxHops limits the number of edges on the whole route; 0 means no limit.
+	MaxHops int
+	// EdgeFilter, when set, admits only the edges for which it returns true.
+	// In an undirected graph it sees each direction of an edge separately.
+	EdgeFilter func(e *Edge) bool
+}
+
+// ShortestPathWith returns the cheapest route from start to goal that
+// satisfies opts, for example "from Arad to Bucharest avoiding Fagaras in at
+// most 4 hops". With waypoints the route is a walk and may pass through a
+// vertex more than once, such as when a waypoint lies off the way.
+//
+// It searches with Dijkstra over states made of a vertex, the number of
+// waypoints reached so far and, with MaxHops, the number of hops used, so
+// it visits O(V * (W+1) * (MaxHops+1)) states. Only reached states take
+// memory, and a hop limit of (W+1) * (V-1) or more is dropped: cutting
+// cycles out of the walk between two waypoints makes any cheapest route
+// that short.
+//
+// It has the same failure contract as ShortestPath and also fails when
+// opts.MaxHops is negative or when start, goal or a waypoint is excluded.
+func (g *Graph) ShortestPathWith(start, goal string, opts PathOptions) ([]string, int, bool) {
+	if !g.HasVertex(start) || !g.HasVertex(goal) || opts.MaxHops < 0 || g.hasNegativeWeightEdge() {
+		return []string{}, 0, false
+	}
+
+	excluded := make(map[string]bool, len(opts.ExcludeVertices))
+	for _, id := range opts.ExcludeVertices {
+		excluded[id] = true
+	}
+	if excluded[start] || excluded[goal] {
+		return []string{}, 0, false
+	}
+
+	ids := g.sortedVertexIDs()
+	index := indexVertexIDs(ids)
+	waypoints := make([]int, len(opts.Waypoints))
+	for i, id := range opts.Waypoints {
+		w, ok := index[id]
+		if !ok || excluded[id] {
+			return []string{}, 0, false
+		}
+		waypoints[i] = w
+	}
+
+	maxHops := opts.MaxHops
+	if bound, ok := mulCost(len(waypoints)+1, len(ids)-1); ok && maxHops >= bound {
+		maxHops = 0
+	}
+	search := &constrainedSearch{
+		adj:       g.constrainedAdjacency(ids, index, excluded, opts),
+		waypoints: waypoints,
+		maxHops:   maxHops,
+	}
+	route, cost, ok := search.run(index[start], index[goal])
+	if !ok {
+		return []string{}, 0, false
+	}
+
+	path := make([]string, len(route))
+	for i, v := range route {
+		path[i] = ids[v]
+	}
+	return path, cost, true
+}
+
+// constrainedAdjacency builds the indexed adjacency restricted to the edges
+// and vertices opts allows.
+func (g *Graph) constrainedAdjacency(ids []string, index map[string]int, excluded map[string]bool, opts PathOptions) [][]indexedEdge {
+	excludedEdges := make(map[[2]string]bool, len(opts.ExcludeEdges))
+	for _, pair := range opts.ExcludeEdges {
+		excludedEdges[pair] = true
+		if !g.directed {
+			excludedEdges[[2]string{pair[1], pair[0]}] = true
+		}
+	}
+
+	adj := g.indexedAdjacency(ids)
+	for u, edges := range adj {
+		if excluded[ids[u]] {
+			adj[u] = nil
+			continue
+		}
+		kept := edges[:0]
+		for _, edge := range edges {
+			from, to := ids[u], ids[edge.to]
+			if excluded[to] || excludedEdges[[2]string{from, to}] {
+				continue
+			}
+			if opts.EdgeFilter != nil && !opts.EdgeFilter(g.vertices[from].edges[to]) {
+				continue
+			}
+			kept = append(kept, edge)
+		}
+		adj[u] = kept
+	}
+	return adj
+}
+
+// constrainedSearch runs Dijkstra over (vertex, stage, hops) states, where
+// stage counts the waypoints reached in order. Arriving at the next waypoint
+// always advances the stage: having reached more waypoints at the same
+// vertex and hop count can only help.
+type constrainedSearch struct {
+	adj       [][]indexedEdge
+	waypoints []int
+	maxHops   int
+}
+
+func (c *constrainedSearch) layers() int {
+	if c.maxHops == 0 {
+		return 1
+	}
+	return c.maxHops + 1
+}
+
+func (c *constrainedSearch) state(v, stage, hops int) int {
+	return (stage*c.layers()+hops)*len(c.adj) + v
+}
+
+// advance returns the stage after arriving at v in the given stage.
+func (c *constrainedSearch) advance(v, stage int) int {
+	if stage < len(c.waypoints) && c.waypoints[stage] == v {
+		return stage + 1
+	}
+	return stage
+}
+
+func (c *constrainedSearch) run(start, goal int) ([]int, int, bool) {
+	n := len(c.adj)
+	// States are numbered densely but stored sparsely; the numbering only
+	// has to fit in an int.
+	size, ok := mulCost(n, len(c.waypoints)+1)
+	if ok {
+		_, ok = mulCost(size, c.layers())
+	}
+	if !ok {
+		return nil, 0, false
+	}
+	dist := make(map[int]int)
+	prev := make(map[int]int)
+
+	first := c.state(start, c.advance(start, 0), 0)
+	dist[first] = 0
+	pq := &indexQueue{{vertex: first, priority: 0}}
+	for pq.Len() > 0 {
+		current := heap.Pop(pq).(indexItem)
+		s := current.vertex
+		if current.priority > dist[s] {
+			continue
+		}
+
+		u := s % n
+		layer := s / n
+		stage, hops := layer/c.layers(), layer%c.layers()
+		if u == goal && stage == len(c.waypoints) {
+			return c.route(prev, s), dist[s], true
+		}
+
+		nextHops := hops
+		if c.maxHops > 0 {
+			if hops == c.maxHops {
+				continue
+			}
+			nextHops++
+		}
+		for _, edge := range c.adj[u] {
+			next := c.state(edge.to, c.advance(edge.to, stage), nextHops)
+			tentative, ok := addCost(dist[s], edge.weight)
+			if !ok {
+				continue
+			}
+			if known, reached := dist[next]; !reached || tentative < known {
+				dist[next] = tentative
+				prev[next] = s
+				heap.Push(pq, indexItem{vertex: next, priority: tentative})
+			}
+		}
+	}
+	return nil, 0, false
+}
+
+// route turns the chain of states ending at s into vertex indices.
+func (c *constrainedSearch) route(prev map[int]int, s int) []int {
+	route := []int{s % len(c.adj)}
+	for state, ok := prev[s]; ok; state, ok = prev[state] {
+		route = append(route, state%len(c.adj))
+	}
+	for i, j := 0, len(route)-1; i < j; i, j = i+1, j-1 {
+		route[i], route[j] = route[j], route[i]
+	}
+	return route
+}
@@ -0,0 +1,128 @@
+package graph
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestShortestPathWithExclusionsAndHops(t *testing.T) {
+	g := BuildRomaniaGraph()
+
+	path, cost, ok := g.ShortestPathWith("Arad", "Bucharest", PathOptions{})
+	if !ok || cost != 418 {
+		t.Fatalf("expected unconstrained cost 418, got %d", cost)
+	}
+
+	path, cost, ok = g.ShortestPathWith("Arad", "Bucharest", PathOptions{
+		ExcludeVertices: []string{"Fagaras"},
+		MaxHops:         4,
+	})
+	if !ok || cost != 418 || len(path)-1 > 4 {
+		t.Fatalf("expected 4-hop route costing 418, got %v %d", path, cost)
+	}
+
+	path, cost, ok = g.ShortestPathWith("Arad", "Bucharest", PathOptions{MaxHops: 3})
+	want := []string{"Arad", "Sibiu", "Fagaras", "Bucharest"}
+	if !ok || cost != 450 || !reflect.DeepEqual(path, want) {
+		t.Fatalf("expected %v costing 450, got %v %d", want, path, cost)
+	}
+
+	if _, _, ok := g.ShortestPathWith("Arad", "Bucharest", PathOptions{MaxHops: 2}); ok {
+		t.Fatal("expected no route within 2 hops")
+	}
+
+	_, cost, ok = g.ShortestPathWith("Arad", "Bucharest", PathOptions{
+		ExcludeEdges: [][2]string{{"Pitesti", "Rimnicu Vilcea"}},
+	})
+	if !ok || cost != 450 {
+		t.Fatalf("expected excluded undirected edge to force Fagaras route, got %d", cost)
+	}
+
+	if _, _, ok := g.ShortestPathWith("Arad", "Bucharest", PathOptions{ExcludeVertices: []string{"Fagaras", "Pitesti"}}); ok {
+		t.Fatal("expected Bucharest to be unreachable without Fagaras and Pitesti")
+	}
+	if _, _, ok := g.ShortestPathWith("Arad", "Bucharest", PathOptions{ExcludeVertices: []string{"Arad"}}); ok {
+		t.Fatal("expected excluded start to fail")
+	}
+	if _, _, ok := g.ShortestPathWith("Arad", "Bucharest", PathOptions{MaxHops: -1}); ok {
+		t.Fatal("expected negative MaxHops to fail")
+	}
+
+	// A hop limit no route can reach is dropped instead of allocating
+	// states for every hop count.
+	_, cost, ok = g.ShortestPathWith("Arad", "Bucharest", PathOptions{
+		MaxHops:   math.MaxInt,
+		Waypoints: []string{"Zerind"},
+	})
+	if !ok || cost != 418+2*75 {
+		t.Fatalf("expected a detour via Zerind costing %d, got %d", 418+2*75, cost)
+	}
+}
+
+func TestShortestPathWithWaypoints(t *testing.T) {
+	g := BuildRomaniaGraph()
+
+	path, cost, ok := g.ShortestPathWith("Arad", "Bucharest", PathOptions{Waypoints: []string{"Craiova"}})
+	// Arad-Sibiu-Rimnicu Vilcea-Craiova (366) then Craiova-Pitesti-Bucharest (239).
+	if !ok || cost != 366+239 {
+		t.Fatalf("expected cost 605 via Craiova, got %v %d", path, cost)
+	}
+
+	// Visiting Zerind then Timisoara forces a return through Arad.
+	path, cost, ok = g.ShortestPathWith("Arad", "Lugoj", PathOptions{Waypoints: []string{"Zerind", "Timisoara"}})
+	want := []string{"Arad", "Zerind", "Arad", "Timisoara", "Lugoj"}
+	if !ok || cost != 75+75+118+111 || !reflect.DeepEqual(path, want) {
+		t.Fatalf("expected %v, got %v %d", want, path, cost)
+	}
+
+	if _, _, ok := g.ShortestPathWith("Arad", "Lugoj", PathOptions{Waypoints: []string{"Zerind", "Timisoara"}, MaxHops: 3}); ok {
+		t.Fatal("expected waypoints to be unreachable within 3 hops")
+	}
+	if _, _, ok := g.ShortestPathWith("Arad", "Bucharest", PathOptions{Waypoints: []string{"Atlantis"}}); ok {
+		t.Fatal("expected unknown waypoint to fail")
+	}
+	if path, _, ok := g.ShortestPathWith("Arad", "Arad", PathOptions{Waypoints: []string{"Arad"}}); !ok || len(path) != 1 {
+		t.Fatal("expected a start that is also the only waypoint to be satisfied")
+	}
+}
+
+func TestShortestPathWithEdgeFilter(t *testing.T) {
+	g := BuildRomaniaGraph()
+	short := func(e *Edge) bool { return e.Weight() < 140 }
+
+	path, cost, ok := g.ShortestPathWith("Arad", "Bucharest", PathOptions{EdgeFilter: short})
+	if !ok {
+		t.Fatal("expected a route using only roads shorter than 140")
+	}
+	for i := 0; i+1 < len(path); i++ {
+		if edge, _ := g.GetEdge(path[i], path[i+1]); edge.Weight() >= 140 {
+			t.Fatalf("route uses filtered road %s-%s", path[i], path[i+1])
+		}
+	}
+	if cost <= 418 {
+		t.Fatalf("expected the filter to make the route dearer, got %d", cost)
+	}
+
+	directed := NewGraph(true)
+	directed.AddEdge("A", "B", 1)
+	directed.AddEdge("B", "C", 1)
+	directed.AddEdge("A", "C", 5)
+	_, cost, _ = directed.ShortestPathWith("A", "C", PathOptions{ExcludeEdges: [][2]string{{"B", "C"}}})
+	if cost != 5 {
+		t.Fatalf("expected the direct edge after excluding B->C, got %d", cost)
+	}
+	_, cost, _ = directed.ShortestPathWith("A", "C", PathOptions{ExcludeEdges: [][2]string{{"C", "B"}}})
+	if cost != 2 {
+		t.Fatalf("expected directed exclusions to be one-way, got %d", cost)
+	}
+}
+
+func TestShortestPathWithCostingExactlyMaxInt(t *testing.T) {
+	g := NewGraph(true)
+	g.AddEdge("A", "B", math.MaxInt)
+
+	if _, cost, ok := g.ShortestPathWith("A", "B", PathOptions{MaxHops: 1}); !ok || cost != math.MaxInt {
+		t.Fatalf("expected A-B costing exactly MaxInt, got %d", cost)
+	}
+}
@@ -0,0 +1,133 @@
+package graph
+
+import (
+	"container/heap"
+	"math"
+	"math/big"
+)
+
+// addCost returns a+b and true, or (0, false) when the sum overflows int.
+// Path algorithms use it so that a path whose cost does not fit in an int is
+// never mistaken for a cheap one.
+func addCost(a, b int) (int, bool) {
+	if (b > 0 && a > math.MaxInt-b) || (b < 0 && a < math.MinInt-b) {
+		return 0, false
+	}
+	return a + b, true
+}
+
+// subCost returns a-b and true, or (0, false) when the difference overflows
+// int.
+func subCost(a, b int) (int, bool) {
+	if (b < 0 && a > math.MaxInt+b) || (b > 0 && a < math.MinInt+b) {
+		return 0, false
+	}
+	return a - b, true
+}
+
+// mulCost returns a*b and true, or (0, false) when the product overflows int.
+func mulCost(a, b int) (int, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	product := a * b
+	if product/b != a || (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) {
+		return 0, false
+	}
+	return product, true
+}
+
+// saturatingAdd returns a+b clamped to [math.MinInt, math.MaxInt].
+func saturatingAdd(a, b int) int {
+	if b > 0 && a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	if b < 0 && a < math.MinInt-b {
+		return math.MinInt
+	}
+	return a + b
+}
+
+// saturatingMul returns a*b clamped to [math.MinInt, math.MaxInt].
+func saturatingMul(a, b int) int {
+	if product, ok := mulCost(a, b); ok {
+		return product
+	}
+	if (a < 0) != (b < 0) {
+		return math.MinInt
+	}
+	return math.MaxInt
+}
+
+// ShortestPathBig is ShortestPath with arbitrary-precision path costs, for
+// graphs whose weights are so large that path costs overflow int. It has the
+// same failure cases as ShortestPath and returns ([]string{}, nil, false) when
+// no path exists.
+func (g *Graph) ShortestPathBig(start, goal string) ([]string, *big.Int, bool) {
+	if !g.HasVertex(start) || !g.HasVertex(goal) || g.hasNegativeWeightEdge() {
+		return []string{}, nil, false
+	}
+	if start == goal {
+		return []string{start}, new(big.Int), true
+	}
+
+	dist := map[string]*big.Int{start: new(big.Int)}
+	prev := make(map[string]string, len(g.vertices))
+
+	pq := &bigQueue{}
+	heap.Push(pq, &bigItem{vertexID: start, priority: new(big.Int)})
+
+	for pq.Len() > 0 {
+		current := heap.Pop(pq).(*bigItem)
+		if current.priority.Cmp(dist[current.vertexID]) > 0 {
+			continue
+		}
+		if current.vertexID == goal {
+			break
+		}
+
+		for neighborID, edge := range g.vertices[current.vertexID].edges {
+			tentative := new(big.Int).Add(current.priority, big.NewInt(int64(edge.weight)))
+			if known, ok := dist[neighborID]; !ok || tentative.Cmp(known) < 0 {
+				dist[neighborID] = tentative
+				prev[neighborID] = current.vertexID
+				heap.Push(pq, &bigItem{vertexID: neighborID, priority: tentative})
+			}
+		}
+	}
+
+	cost, ok := dist[goal]
+	if !ok {
+		return []string{}, nil, false
+	}
+	return buildPath(prev, start, goal), cost, true
+}
+
+type bigItem struct {
+	vertexID string
+	priority *big.Int
+}
+
+type bigQueue []*bigItem
+
+func (pq bigQueue) Len() int { return len(pq) }
+
+func (pq bigQueue) Less(i, j int) bool {
+	return pq[i].priority.Cmp(pq[j].priority) < 0
+}
+
+func (pq bigQueue) Swap(i, j int) {
+	pq[i], pq[j] = pq[j], pq[i]
+}
+
+func (pq *bigQueue) Push(x any) {
+	*pq = append(*pq, x.(*bigItem))
+}
+
+func (pq *bigQueue) Pop() any {
+	old := *pq
+	n := len(old)
+	item := old[n-1]
+	*pq = old[:n-1]
+	return item
+}
@@ -0,0 +1,158 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestCostArithmetic(t *testing.T) {
+	if _, ok := addCost(math.MaxInt, 1); ok {
+		t.Fatal("expected addCost to detect positive overflow")
+	}
+	if _, ok := addCost(math.MinInt, -1); ok {
+		t.Fatal("expected addCost to detect negative overflow")
+	}
+	if sum, ok := addCost(math.MaxInt-1, 1); !ok || sum != math.MaxInt {
+		t.Fatal("expected MaxInt-1 + 1 to fit")
+	}
+	if _, ok := subCost(0, math.MinInt); ok {
+		t.Fatal("expected subCost to detect positive overflow")
+	}
+	if diff, ok := subCost(-1, math.MaxInt); !ok || diff != math.MinInt {
+		t.Fatal("expected -1 - MaxInt to fit")
+	}
+	if _, ok := mulCost(math.MaxInt/2, 3); ok {
+		t.Fatal("expected mulCost to detect overflow")
+	}
+	if saturatingAdd(math.MaxInt, 5) != math.MaxInt || saturatingAdd(math.MinInt, -5) != math.MinInt {
+		t.Fatal("expected saturatingAdd to clamp")
+	}
+	if saturatingMul(math.MaxInt/2, 3) != math.MaxInt || saturatingMul(math.MaxInt/2, -3) != math.MinInt {
+		t.Fatal("expected saturatingMul to clamp")
+	}
+	if saturatingMul(-1, math.MinInt) != math.MaxInt || saturatingMul(6, 7) != 42 {
+		t.Fatal("expected saturatingMul to multiply and clamp")
+	}
+}
+
+// hugeWeightGraph has a route A-B-D whose cost wraps around to a negative
+// number with unchecked arithmetic, and a dearer but representable route A-C-D.
+func hugeWeightGraph() *Graph {
+	g := NewGraph(true)
+	g.AddEdge("A", "B", math.MaxInt-10)
+	g.AddEdge("B", "D", 20)
+	g.AddEdge("A", "C", math.MaxInt/2)
+	g.AddEdge("C", "D", math.MaxInt/2)
+	return g
+}
+
+func TestShortestPathIgnoresOverflowingRoutes(t *testing.T) {
+	g := hugeWeightGraph()
+
+	path, cost, ok := g.ShortestPath("A", "D")
+	if !ok || cost != math.MaxInt-1 {
+		t.Fatalf("expected representable route costing MaxInt-1, got %v %d", path, cost)
+	}
+	if path[1] != "C" {
+		t.Fatalf("expected route through C, got %v", path)
+	}
+
+	zero := func(_, _ *Vertex) int { return 0 }
+	if _, cost, ok := g.AStar("A", "D", zero); !ok || cost != math.MaxInt-1 {
+		t.Fatalf("expected A* to agree, got %d", cost)
+	}
+}
+
+func TestCheckedErrors(t *testing.T) {
+	g := NewGraph(true)
+	g.AddEdge("A", "B", math.MaxInt)
+	g.AddEdge("B", "C", 1)
+	g.AddVertex("D")
+
+	if _, _, err := g.ShortestPathChecked("A", "C"); !errors.Is(err, ErrCostOverflow) {
+		t.Fatalf("expected ErrCostOverflow, got %v", err)
+	}
+	if _, _, ok := g.ShortestPath("A", "C"); ok {
+		t.Fatal("expected ShortestPath to report overflowing route as not found")
+	}
+	if _, _, err := g.ShortestPathChecked("A", "D"); !errors.Is(err, ErrNoPath) {
+		t.Fatalf("expected ErrNoPath, got %v", err)
+	}
+	if _, _, err := g.ShortestPathChecked("A", "Z"); !errors.Is(err, ErrVertexNotFound) {
+		t.Fatalf("expected ErrVertexNotFound, got %v", err)
+	}
+	if _, _, err := g.AStarChecked("A", "C", nil); !errors.Is(err, ErrNilHeuristic) {
+		t.Fatalf("expected ErrNilHeuristic, got %v", err)
+	}
+	huge := func(_, _ *Vertex) int { return math.MaxInt }
+	if _, _, err := g.AStarChecked("A", "C", huge); !errors.Is(err, ErrCostOverflow) {
+		t.Fatalf("expected ErrCostOverflow from A*, got %v", err)
+	}
+
+	g.AddEdge("C", "A", -1)
+	if _, _, err := g.ShortestPathChecked("A", "B"); !errors.Is(err, ErrNegativeWeight) {
+		t.Fatalf("expected ErrNegativeWeight, got %v", err)
+	}
+}
+
+func TestShortestPathCostingExactlyMaxInt(t *testing.T) {
+	g := NewGraph(true)
+	g.AddEdge("S", "T", math.MaxInt)
+
+	path, cost, err := g.ShortestPathChecked("S", "T")
+	if err != nil || cost != math.MaxInt || len(path) != 2 {
+		t.Fatalf("expected S-T costing MaxInt, got %v %d %v", path, cost, err)
+	}
+	zero := func(_, _ *Vertex) int { return 0 }
+	if _, cost, err := g.AStarChecked("S", "T", zero); err != nil || cost != math.MaxInt {
+		t.Fatalf("expected A* to find S-T costing MaxInt, got %d %v", cost, err)
+	}
+}
+
+func TestShortestPathBig(t *testing.T) {
+	g := NewGraph(false)
+	g.AddEdge("A", "B", math.MaxInt)
+	g.AddEdge("B", "C", math.MaxInt)
+	g.AddEdge("A", "X", 1)
+
+	path, cost, ok := g.ShortestPathBig("A", "C")
+	if !ok || len(path) != 3 {
+		t.Fatalf("expected path A-B-C, got %v", path)
+	}
+	want := new(big.Int).Mul(big.NewInt(math.MaxInt), big.NewInt(2))
+	if cost.Cmp(want) != 0 {
+		t.Fatalf("expected cost %s, got %s", want, cost)
+	}
+
+	romania := BuildRomaniaGraph()
+	if _, cost, ok := romania.ShortestPathBig("Arad", "Bucharest"); !ok || cost.Int64() != 418 {
+		t.Fatal("expected Arad to Bucharest costing 418")
+	}
+	if _, _, ok := g.ShortestPathBig("A", "Z"); ok {
+		t.Fatal("expected unknown vertex to fail")
+	}
+}
+
+func TestOverflowSafeAlgorithms(t *testing.T) {
+	g := NewGraph(false)
+	g.AddEdge("A", "B", math.MaxInt/2)
+	g.AddEdge("B", "C", math.MaxInt/2)
+	g.AddEdge("C", "A", math.MaxInt/2)
+
+	if _, _, ok := g.TSP(nil); ok {
+		t.Fatal("expected TSP to reject distances whose tour cost overflows")
+	}
+	if _, _, ok := g.ChinesePostman(); ok {
+		t.Fatal("expected ChinesePostman to reject an overflowing walk cost")
+	}
+	if _, _, ok := g.ExpandTour([]string{"A", "B", "C", "A"}); ok {
+		t.Fatal("expected ExpandTour to reject an overflowing total")
+	}
+
+	closeness := g.ClosenessCentrality(true)
+	if closeness["A"] <= 0 {
+		t.Fatalf("expected positive closeness, got %v", closeness["A"])
+	}
+}
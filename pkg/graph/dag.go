@@ -0,0 +1,388 @@
+package graph
+
+import (
+	"container/heap"
+	"math"
+)
+
+// TopologicalSort returns the vertex IDs ordered so that every edge goes from
+// an earlier vertex to a later one, using Kahn's algorithm. Among vertices
+// that are ready at the same time, lower IDs come first, so the order is
+// deterministic; with the ready vertices kept in a heap for that, and the
+// vertex IDs and edge lists sorted up front, it runs in O(V log V + E log E).
+// It returns (nil, false) for undirected graphs and for graphs with a cycle.
+func (g *Graph) TopologicalSort() ([]string, bool) {
+	if !g.directed {
+		return nil, false
+	}
+	ids := g.sortedVertexIDs()
+	order, ok := lowestFirstTopologicalOrder(g.indexedAdjacency(ids))
+	if !ok {
+		return nil, false
+	}
+	sorted := make([]string, len(order))
+	for i, v := range order {
+		sorted[i] = ids[v]
+	}
+	return sorted, true
+}
+
+// TransitiveClosure returns a directed graph with the vertices of g and an
+// edge u -> v whenever v can be reached from u by a path of one or more
+// edges. Edges of g are copied with their weights and capacities; implied
+// edges have weight 0. Cycles are allowed, but since graphs have no
+// self-loops a vertex is never joined to itself. It searches from every
+// vertex, O(V * (V + E)), and returns (nil, false) for undirected graphs.
+func (g *Graph) TransitiveClosure() (*Graph, bool) {
+	if !g.directed {
+		return nil, false
+	}
+
+	ids := g.sortedVertexIDs()
+	adj := g.indexedAdjacency(ids)
+	closure := g.Clone()
+	for s := range adj {
+		visited := make([]bool, len(adj))
+		stack := []int{s}
+		for len(stack) > 0 {
+			u := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, edge := range adj[u] {
+				if visited[edge.to] {
+					continue
+				}
+				visited[edge.to] = true
+				stack = append(stack, edge.to)
+				if edge.to != s && !closure.HasEdge(ids[s], ids[edge.to]) {
+					closure.AddEdge(ids[s], ids[edge.to], 0)
+				}
+			}
+		}
+	}
+	return closure, true
+}
+
+// TransitiveReduction returns the smallest subgraph of a DAG with the same
+// reachability: it drops every edge u -> v for which another path from u to
+// v exists. Kept edges retain their weights and capacities. Reachability is
+// propagated as bit sets in reverse topological order, O(V * E / 64) on top
+// of sorting the vertices and edges. It returns (nil, false) for undirected
+// graphs and for graphs with a cycle, whose reduction is not unique.
+func (g *Graph) TransitiveReduction() (*Graph, bool) {
+	if !g.directed {
+		return nil, false
+	}
+
+	ids := g.sortedVertexIDs()
+	adj := g.indexedAdjacency(ids)
+	order, ok := topologicalOrder(adj)
+	if !ok {
+		return nil, false
+	}
+
+	// reach[u] holds every vertex reachable from u by one or more edges.
+	// An edge u -> v is redundant when v is reachable from u by two or more
+	// edges, that is from some successor of u; a DAG has no path from v back
+	// to v, so v's own reach set cannot contain it.
+	words := (len(ids) + 63) / 64
+	reach := make([][]uint64, len(ids))
+	redundant := make(map[[2]string]bool)
+	for i := len(order) - 1; i >= 0; i-- {
+		u := order[i]
+		reach[u] = make([]uint64, words)
+		for _, edge := range adj[u] {
+			for w, word := range reach[edge.to] {
+				reach[u][w] |= word
+			}
+		}
+		for _, edge := range adj[u] {
+			if hasBit(reach[u], edge.to) {
+				redundant[[2]string{ids[u], ids[edge.to]}] = true
+			}
+		}
+		for _, edge := range adj[u] {
+			setBit(reach[u], edge.to)
+		}
+	}
+	return g.EdgeSubgraph(func(e *Edge) bool {
+		return !redundant[[2]string{e.from.id, e.to.id}]
+	}), true
+}
+
+// Schedule is the result of the critical path method. Vertices are events
+// and edge weights are the durations of the activities between them.
+type Schedule struct {
+	// Duration is the length of the longest path, the shortest possible
+	// duration of the whole project.
+	Duration int
+	// CriticalPath is a longest path. Delaying any of its vertices delays the
+	// project.
+	CriticalPath []string
+	// Earliest is the earliest time each event can happen: the length of the
+	// longest path ending at it, and at least 0.
+	Earliest map[string]int
+	// Latest is the latest time each event can happen without delaying the
+	// project.
+	Latest map[string]int
+	// Slack is Latest minus Earliest. Critical events have slack 0.
+	Slack map[string]int
+}
+
+// CriticalPath schedules a DAG with the critical path method: a forward pass
+// in topological order computes earliest times and a backward pass latest
+// times. Negative weights are allowed. The critical path ends at the lowest
+// ID among the events finishing last and steps back through the predecessor
+// with the lowest ID that determines each earliest time. Both passes run in
+// O(V + E), but indexing the graph sorts its vertex IDs and edge lists first,
+// so a call costs O(V log V + E log E). It returns false for undirected
+// graphs, for graphs with a cycle and when a path length overflows int.
+func (g *Graph) CriticalPath() (Schedule, bool) {
+	if !g.directed {
+		return Schedule{}, false
+	}
+
+	ids := g.sortedVertexIDs()
+	adj := g.indexedAdjacency(ids)
+	order, ok := topologicalOrder(adj)
+	if !ok {
+		return Schedule{}, false
+	}
+
+	earliest := make([]int, len(ids))
+	for _, u := range order {
+		for _, edge := range adj[u] {
+			candidate, ok := addCost(earliest[u], edge.weight)
+			if !ok {
+				return Schedule{}, false
+			}
+			earliest[edge.to] = max(earliest[edge.to], candidate)
+		}
+	}
+
+	duration, last := 0, -1
+	for v, t := range earliest {
+		if last < 0 || t > duration {
+			duration, last = t, v
+		}
+	}
+
+	latest := make([]int, len(ids))
+	for i := len(order) - 1; i >= 0; i-- {
+		u := order[i]
+		latest[u] = duration
+		for _, edge := range adj[u] {
+			if edge.weight == math.MinInt {
+				return Schedule{}, false
+			}
+			candidate, ok := addCost(latest[edge.to], -edge.weight)
+			if !ok {
+				return Schedule{}, false
+			}
+			latest[u] = min(latest[u], candidate)
+		}
+	}
+
+	schedule := Schedule{
+		Duration: duration,
+		Earliest: make(map[string]int, len(ids)),
+		Latest:   make(map[string]int, len(ids)),
+		Slack:    make(map[string]int, len(ids)),
+	}
+	for v, id := range ids {
+		schedule.Earliest[id] = earliest[v]
+		schedule.Latest[id] = latest[v]
+		schedule.Slack[id] = latest[v] - earliest[v]
+	}
+	if last >= 0 {
+		schedule.CriticalPath = criticalPath(adj, earliest, last, ids)
+	}
+	return schedule, true
+}
+
+// LongestPath returns a longest path of a DAG and its length, the critical
+// path and duration of CriticalPath. Negative weights are allowed; a path may
+// consist of a single vertex, so the length is never negative. It returns
+// (nil, 0, false) in the cases CriticalPath fails.
+func (g *Graph) LongestPath() ([]string, int, bool) {
+	schedule, ok := g.CriticalPath()
+	if !ok {
+		return nil, 0, false
+	}
+	return schedule.CriticalPath, schedule.Duration, true
+}
+
+// DAGShortestPath returns the shortest path between start and goal of a DAG
+// by relaxing edges in topological order. Unlike ShortestPath it allows
+// negative weights. The relaxation runs in O(V + E); sorting the vertex IDs
+// and edge lists, which keeps ties deterministic, makes a call
+// O(V log V + E log E). It returns ([]string{}, 0, false) for undirected
+// graphs, for graphs with a cycle and when goal cannot be reached from start
+// without overflowing int.
+func (g *Graph) DAGShortestPath(start, goal string) ([]string, int, bool) {
+	ids, dist, prev, reached, ok := g.dagShortestPaths(start)
+	if !ok {
+		return []string{}, 0, false
+	}
+	t, ok := indexVertexIDs(ids)[goal]
+	if !ok || !reached[t] {
+		return []string{}, 0, false
+	}
+
+	route := treePath(prev, t)
+	path := make([]string, len(route))
+	for i, v := range route {
+		path[i] = ids[v]
+	}
+	return path, dist[t], true
+}
+
+// DAGDistances returns the shortest distance from source to every vertex of
+// a DAG it reaches, allowing negative weights. Like DAGShortestPath it costs
+// O(V log V + E log E). It returns (nil, false) for undirected graphs,
+// graphs with a cycle and unknown sources.
+func (g *Graph) DAGDistances(source string) (map[string]int, bool) {
+	ids, dist, _, reached, ok := g.dagShortestPaths(source)
+	if !ok {
+		return nil, false
+	}
+	distances := make(map[string]int)
+	for v, d := range dist {
+		if reached[v] {
+			distances[ids[v]] = d
+		}
+	}
+	return distances, true
+}
+
+// dagShortestPaths returns the sorted vertex IDs with the distance from
+// source and the predecessor (-1 at the root) of each, and which vertices
+// source reaches. Distances of unreached vertices are meaningless.
+func (g *Graph) dagShortestPaths(source string) ([]string, []int, []int, []bool, bool) {
+	if !g.directed {
+		return nil, nil, nil, nil, false
+	}
+	ids := g.sortedVertexIDs()
+	s, ok := indexVertexIDs(ids)[source]
+	if !ok {
+		return nil, nil, nil, nil, false
+	}
+	adj := g.indexedAdjacency(ids)
+	order, ok := topologicalOrder(adj)
+	if !ok {
+		return nil, nil, nil, nil, false
+	}
+
+	dist := make([]int, len(ids))
+	prev := make([]int, len(ids))
+	reached := make([]bool, len(ids))
+	for i := range prev {
+		prev[i] = -1
+	}
+	reached[s] = true
+	for _, u := range order {
+		if !reached[u] {
+			continue
+		}
+		for _, edge := range adj[u] {
+			candidate, ok := addCost(dist[u], edge.weight)
+			if ok && (!reached[edge.to] || candidate < dist[edge.to]) {
+				reached[edge.to] = true
+				dist[edge.to] = candidate
+				prev[edge.to] = u
+			}
+		}
+	}
+	return ids, dist, prev, reached, true
+}
+
+// indegrees returns the number of inbound edges of every vertex of adj.
+func indegrees(adj [][]indexedEdge) []int {
+	indegree := make([]int, len(adj))
+	for _, edges := range adj {
+		for _, edge := range edges {
+			indegree[edge.to]++
+		}
+	}
+	return indegree
+}
+
+// topologicalOrder runs Kahn's algorithm on adj in O(V + E), taking ready
+// vertices first in, first out, and returns false if adj has a cycle.
+func topologicalOrder(adj [][]indexedEdge) ([]int, bool) {
+	indegree := indegrees(adj)
+	ready := make([]int, 0)
+	for v, d := range indegree {
+		if d == 0 {
+			ready = append(ready, v)
+		}
+	}
+	order := make([]int, 0, len(adj))
+	for len(ready) > 0 {
+		u := ready[0]
+		ready = ready[1:]
+		order = append(order, u)
+		for _, edge := range adj[u] {
+			indegree[edge.to]--
+			if indegree[edge.to] == 0 {
+				ready = append(ready, edge.to)
+			}
+		}
+	}
+	return order, len(order) == len(adj)
+}
+
+// lowestFirstTopologicalOrder is topologicalOrder taking the ready vertex
+// with the lowest index first, in O(V log V + E).
+func lowestFirstTopologicalOrder(adj [][]indexedEdge) ([]int, bool) {
+	indegree := indegrees(adj)
+	ready := &indexQueue{}
+	for v, d := range indegree {
+		if d == 0 {
+			heap.Push(ready, indexItem{vertex: v})
+		}
+	}
+	order := make([]int, 0, len(adj))
+	for ready.Len() > 0 {
+		u := heap.Pop(ready).(indexItem).vertex
+		order = append(order, u)
+		for _, edge := range adj[u] {
+			indegree[edge.to]--
+			if indegree[edge.to] == 0 {
+				heap.Push(ready, indexItem{vertex: edge.to})
+			}
+		}
+	}
+	return order, len(order) == len(adj)
+}
+
+// criticalPath walks back from last through predecessors whose earliest time
+// plus the edge weight gives the earliest time of the next event.
+func criticalPath(adj [][]indexedEdge, earliest []int, last int, ids []string) []string {
+	preds := make([][]indexedEdge, len(adj))
+	for u, edges := range adj {
+		for _, edge := range edges {
+			preds[edge.to] = append(preds[edge.to], indexedEdge{to: u, weight: edge.weight})
+		}
+	}
+
+	path := []string{ids[last]}
+	for v := last; earliest[v] > 0; {
+		next := -1
+		for _, pred := range preds[v] {
+			if earliest[pred.to]+pred.weight == earliest[v] && (next < 0 || pred.to < next) {
+				next = pred.to
+			}
+		}
+		if next < 0 {
+			break
+		}
+		path = append(path, ids[next])
+		v = next
+	}
+	reverseStrings(path)
+	return path
+}
+
+func setBit(set []uint64, i int) { set[i/64] |= 1 << (i % 64) }
+
+func hasBit(set []uint64, i int) bool { return set[i/64]&(1<<(i%64)) != 0 }
@@ -0,0 +1,165 @@
+package graph
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func buildDAG(edges [][3]any) *Graph {
+	g := NewGraph(true)
+	for _, e := range edges {
+		g.AddVertex(e[0].(string))
+		g.AddVertex(e[1].(string))
+		g.AddEdge(e[0].(string), e[1].(string), e[2].(int))
+	}
+	return g
+}
+
+func directedCycle() *Graph {
+	return buildDAG([][3]any{{"a", "b", 1}, {"b", "c", 1}, {"c", "a", 1}})
+}
+
+// projectGraph is a small activity-on-arc project: weights are durations.
+func projectGraph() *Graph {
+	return buildDAG([][3]any{
+		{"S", "A", 3}, {"S", "B", 2},
+		{"A", "C", 4}, {"B", "C", 2},
+		{"B", "F", 4}, {"C", "F", 3},
+	})
+}
+
+func TestTopologicalSort(t *testing.T) {
+	order, ok := projectGraph().TopologicalSort()
+	expected := []string{"S", "A", "B", "C", "F"}
+	if !ok || !reflect.DeepEqual(order, expected) {
+		t.Fatalf("expected %v, got %v", expected, order)
+	}
+
+	if _, ok := directedCycle().TopologicalSort(); ok {
+		t.Fatal("expected a cyclic graph to have no topological order")
+	}
+	if _, ok := NewGraph(false).TopologicalSort(); ok {
+		t.Fatal("expected undirected graphs to be rejected")
+	}
+}
+
+func TestTransitiveClosure(t *testing.T) {
+	g := buildDAG([][3]any{{"a", "b", 5}, {"b", "c", 7}, {"c", "d", 1}})
+	closure, ok := g.TransitiveClosure()
+	if !ok || len(closure.GetEdges()) != 6 {
+		t.Fatalf("expected 6 closure edges, got %d", len(closure.GetEdges()))
+	}
+	if edge, _ := closure.GetEdge("a", "b"); edge.Weight() != 5 {
+		t.Fatalf("expected original edge weight 5, got %d", edge.Weight())
+	}
+	if edge, ok := closure.GetEdge("a", "d"); !ok || edge.Weight() != 0 {
+		t.Fatal("expected implied edge a -> d with weight 0")
+	}
+	if closure.HasEdge("d", "a") || g.HasEdge("a", "c") {
+		t.Fatal("expected closure to follow direction and leave g unchanged")
+	}
+
+	cyclic := buildDAG([][3]any{{"a", "b", 1}, {"b", "a", 1}, {"b", "c", 1}})
+	closure, _ = cyclic.TransitiveClosure()
+	if len(closure.GetEdges()) != 4 || !closure.HasEdge("a", "c") || closure.HasEdge("a", "a") {
+		t.Fatalf("expected a <-> b plus a, b -> c without self-loops, got %d edges", len(closure.GetEdges()))
+	}
+}
+
+func TestTransitiveReduction(t *testing.T) {
+	g := buildDAG([][3]any{
+		{"a", "b", 1}, {"b", "c", 1}, {"a", "c", 9},
+		{"c", "d", 1}, {"a", "d", 9}, {"b", "d", 9},
+	})
+	reduction, ok := g.TransitiveReduction()
+	if !ok {
+		t.Fatal("expected reduction to succeed")
+	}
+	if len(reduction.GetEdges()) != 3 || !reduction.HasEdge("a", "b") || !reduction.HasEdge("b", "c") || !reduction.HasEdge("c", "d") {
+		t.Fatalf("expected only the chain a -> b -> c -> d, got %d edges", len(reduction.GetEdges()))
+	}
+
+	// The reduction has the same closure as the original graph.
+	before, _ := g.TransitiveClosure()
+	after, _ := reduction.TransitiveClosure()
+	if len(before.GetEdges()) != len(after.GetEdges()) {
+		t.Fatalf("expected equal reachability, got %d and %d closure edges", len(before.GetEdges()), len(after.GetEdges()))
+	}
+
+	if _, ok := directedCycle().TransitiveReduction(); ok {
+		t.Fatal("expected cyclic graphs to be rejected")
+	}
+}
+
+func TestCriticalPath(t *testing.T) {
+	schedule, ok := projectGraph().CriticalPath()
+	if !ok || schedule.Duration != 10 {
+		t.Fatalf("expected duration 10, got %+v", schedule)
+	}
+	if expected := []string{"S", "A", "C", "F"}; !reflect.DeepEqual(schedule.CriticalPath, expected) {
+		t.Fatalf("expected critical path %v, got %v", expected, schedule.CriticalPath)
+	}
+
+	earliest := map[string]int{"S": 0, "A": 3, "B": 2, "C": 7, "F": 10}
+	latest := map[string]int{"S": 0, "A": 3, "B": 5, "C": 7, "F": 10}
+	if !reflect.DeepEqual(schedule.Earliest, earliest) || !reflect.DeepEqual(schedule.Latest, latest) {
+		t.Fatalf("expected earliest %v and latest %v, got %v and %v", earliest, latest, schedule.Earliest, schedule.Latest)
+	}
+	for id, slack := range schedule.Slack {
+		if expected := latest[id] - earliest[id]; slack != expected {
+			t.Fatalf("expected slack %d for %s, got %d", expected, id, slack)
+		}
+	}
+
+	path, length, ok := projectGraph().LongestPath()
+	if !ok || length != 10 || len(path) != 4 {
+		t.Fatalf("expected a longest path of length 10, got %v %d", path, length)
+	}
+	if _, _, ok := directedCycle().LongestPath(); ok {
+		t.Fatal("expected cyclic graphs to be rejected")
+	}
+}
+
+func TestDAGShortestPathNegativeWeights(t *testing.T) {
+	g := buildDAG([][3]any{{"s", "a", 2}, {"s", "b", 5}, {"a", "b", -4}, {"b", "c", 1}, {"d", "c", 1}})
+	path, cost, ok := g.DAGShortestPath("s", "c")
+	if expected := []string{"s", "a", "b", "c"}; !ok || cost != -1 || !reflect.DeepEqual(path, expected) {
+		t.Fatalf("expected %v at cost -1, got %v %d", expected, path, cost)
+	}
+	if _, _, ok := g.ShortestPath("s", "c"); ok {
+		t.Fatal("expected Dijkstra to reject negative weights")
+	}
+	if _, _, ok := g.DAGShortestPath("s", "d"); ok {
+		t.Fatal("expected unreachable goal to fail")
+	}
+
+	distances, ok := g.DAGDistances("s")
+	expected := map[string]int{"s": 0, "a": 2, "b": -2, "c": -1}
+	if !ok || !reflect.DeepEqual(distances, expected) {
+		t.Fatalf("expected %v, got %v", expected, distances)
+	}
+	if _, ok := g.DAGDistances("x"); ok {
+		t.Fatal("expected unknown source to fail")
+	}
+}
+
+func TestDAGShortestPathCostingExactlyMaxInt(t *testing.T) {
+	g := buildDAG([][3]any{{"s", "t", math.MaxInt}})
+	if _, cost, ok := g.DAGShortestPath("s", "t"); !ok || cost != math.MaxInt {
+		t.Fatalf("expected s-t costing MaxInt, got %d", cost)
+	}
+	if distances, ok := g.DAGDistances("s"); !ok || distances["t"] != math.MaxInt {
+		t.Fatalf("expected t at distance MaxInt, got %v", distances)
+	}
+}
+
+func TestDAGShortestPathMatchesDijkstra(t *testing.T) {
+	g := projectGraph()
+	for _, goal := range []string{"A", "B", "C", "F"} {
+		_, want, _ := g.ShortestPath("S", goal)
+		if _, got, ok := g.DAGShortestPath("S", goal); !ok || got != want {
+			t.Fatalf("expected cost %d to %s, got %d", want, goal, got)
+		}
+	}
+}
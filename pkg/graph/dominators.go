@@ -0,0 +1,293 @@
+package graph
+
+import "sort"
+
+// Dominators holds the dominance relation of a directed graph rooted at an
+// entry vertex, as used in control-flow analysis. A vertex a dominates b when
+// every path from the entry to b passes through a; every vertex dominates
+// itself. Only vertices reachable from the entry take part: queries about
+// other vertices report nothing.
+type Dominators struct {
+	entry    string
+	ids      []string       // reachable vertices in reverse postorder
+	index    map[string]int // position of each vertex in ids
+	idom     []int          // idom[v] is the immediate dominator of v; idom[0] = 0
+	children [][]int        // children in the dominator tree, sorted by ID
+	frontier [][]int        // dominance frontier of each vertex, sorted by ID
+	pre      []int          // preorder number in the dominator tree
+	post     []int          // largest preorder number in v's subtree
+}
+
+// Dominators computes the dominator tree of the vertices reachable from entry
+// with the iterative algorithm of Cooper, Harvey and Kennedy: immediate
+// dominators are refined in reverse postorder until they settle, by walking
+// the partial tree up from the predecessors of each vertex. Dominance
+// frontiers follow from the tree in a second pass. It runs in O(V + E) per
+// pass and needs few passes on typical control-flow graphs.
+//
+// It returns (nil, false) for undirected graphs and unknown entries.
+func (g *Graph) Dominators(entry string) (*Dominators, bool) {
+	if !g.directed || !g.HasVertex(entry) {
+		return nil, false
+	}
+
+	// Number the reachable vertices in reverse postorder, successors taken in
+	// ID order so the numbering is deterministic.
+	all := g.sortedVertexIDs()
+	allIndex := indexVertexIDs(all)
+	adj := g.indexedAdjacency(all)
+	visited := make([]bool, len(all))
+	postorder := make([]int, 0, len(all))
+	type frame struct{ v, next int }
+	stack := []frame{{v: allIndex[entry]}}
+	visited[allIndex[entry]] = true
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.next < len(adj[top.v]) {
+			to := adj[top.v][top.next].to
+			top.next++
+			if !visited[to] {
+				visited[to] = true
+				stack = append(stack, frame{v: to})
+			}
+			continue
+		}
+		postorder = append(postorder, top.v)
+		stack = stack[:len(stack)-1]
+	}
+
+	n := len(postorder)
+	d := &Dominators{
+		entry: entry,
+		ids:   make([]string, n),
+		index: make(map[string]int, n),
+	}
+	rpo := make([]int, len(all)) // position in reverse postorder, or -1
+	for i := range rpo {
+		rpo[i] = -1
+	}
+	for i, v := range postorder {
+		position := n - 1 - i
+		rpo[v] = position
+		d.ids[position] = all[v]
+		d.index[all[v]] = position
+	}
+
+	preds := make([][]int, n)
+	for u, edges := range adj {
+		if rpo[u] < 0 {
+			continue
+		}
+		for _, edge := range edges {
+			preds[rpo[edge.to]] = append(preds[rpo[edge.to]], rpo[u])
+		}
+	}
+
+	d.idom = make([]int, n)
+	for i := range d.idom {
+		d.idom[i] = -1
+	}
+	d.idom[0] = 0
+	for changed := true; changed; {
+		changed = false
+		for v := 1; v < n; v++ {
+			candidate := -1
+			for _, p := range preds[v] {
+				if d.idom[p] < 0 {
+					continue
+				}
+				if candidate < 0 {
+					candidate = p
+				} else {
+					candidate = d.intersect(p, candidate)
+				}
+			}
+			if candidate != d.idom[v] {
+				d.idom[v] = candidate
+				changed = true
+			}
+		}
+	}
+
+	d.children = make([][]int, n)
+	for v := 1; v < n; v++ {
+		d.children[d.idom[v]] = append(d.children[d.idom[v]], v)
+	}
+	for _, list := range d.children {
+		d.sortByID(list)
+	}
+	d.numberTree()
+	d.computeFrontiers(preds)
+	return d, true
+}
+
+// PostDominators computes post-dominators with respect to exit: a vertex a
+// post-dominates b when every path from b to exit passes through a. It is the
+// dominator analysis of the transposed graph rooted at exit, so the returned
+// tree and frontiers describe the reversed edges; post-dominance frontiers
+// give the control dependences of a control-flow graph. Graphs with several
+// exits need a single virtual exit joined to all of them.
+//
+// It returns (nil, false) for undirected graphs and unknown exits.
+func (g *Graph) PostDominators(exit string) (*Dominators, bool) {
+	if !g.directed {
+		return nil, false
+	}
+	return g.Transpose().Dominators(exit)
+}
+
+// Entry returns the root of the dominator tree.
+func (d *Dominators) Entry() string {
+	return d.entry
+}
+
+// Reachable reports whether id is reachable from the entry and so takes part
+// in the analysis.
+func (d *Dominators) Reachable(id string) bool {
+	_, ok := d.index[id]
+	return ok
+}
+
+// ImmediateDominator returns the closest strict dominator of id, its parent in
+// the dominator tree. It returns ("", false) for the entry and for vertices
+// that are not reachable.
+func (d *Dominators) ImmediateDominator(id string) (string, bool) {
+	v, ok := d.index[id]
+	if !ok || v == 0 {
+		return "", false
+	}
+	return d.ids[d.idom[v]], true
+}
+
+// Dominates reports whether a dominates b. Every reachable vertex dominates
+// itself. It answers in O(1) from preorder intervals of the dominator tree.
+func (d *Dominators) Dominates(a, b string) bool {
+	u, okA := d.index[a]
+	v, okB := d.index[b]
+	if !okA || !okB {
+		return false
+	}
+	return d.pre[u] <= d.pre[v] && d.pre[v] <= d.post[u]
+}
+
+// Children returns the vertices id immediately dominates, its children in the
+// dominator tree, sorted by ID.
+func (d *Dominators) Children(id string) []string {
+	v, ok := d.index[id]
+	if !ok {
+		return []string{}
+	}
+	return d.names(d.children[v])
+}
+
+// Tree returns the dominator tree as a new directed graph with an edge of
+// weight 0 from every immediate dominator to the vertices it dominates.
+func (d *Dominators) Tree() *Graph {
+	tree := NewGraph(true)
+	for _, id := range d.ids {
+		tree.AddVertex(id)
+	}
+	for v := 1; v < len(d.ids); v++ {
+		tree.AddEdge(d.ids[d.idom[v]], d.ids[v], 0)
+	}
+	return tree
+}
+
+// Frontier returns the dominance frontier of id, sorted by ID: the vertices
+// where the dominance of id ends, that is vertices with a predecessor
+// dominated by id that id does not strictly dominate. In SSA construction
+// they are where phi functions for variables assigned in id are placed.
+func (d *Dominators) Frontier(id string) []string {
+	v, ok := d.index[id]
+	if !ok {
+		return []string{}
+	}
+	return d.names(d.frontier[v])
+}
+
+// Frontiers returns the dominance frontier of every reachable vertex.
+func (d *Dominators) Frontiers() map[string][]string {
+	frontiers := make(map[string][]string, len(d.ids))
+	for v, id := range d.ids {
+		frontiers[id] = d.names(d.frontier[v])
+	}
+	return frontiers
+}
+
+// intersect returns the nearest common ancestor of a and b in the partial
+// dominator tree. Ancestors come earlier in reverse postorder.
+func (d *Dominators) intersect(a, b int) int {
+	for a != b {
+		for a > b {
+			a = d.idom[a]
+		}
+		for b > a {
+			b = d.idom[b]
+		}
+	}
+	return a
+}
+
+// numberTree assigns preorder numbers and subtree intervals for Dominates.
+func (d *Dominators) numberTree() {
+	n := len(d.ids)
+	d.pre = make([]int, n)
+	d.post = make([]int, n)
+	counter := 0
+	var visit func(v int)
+	visit = func(v int) {
+		d.pre[v] = counter
+		counter++
+		for _, c := range d.children[v] {
+			visit(c)
+		}
+		d.post[v] = counter - 1
+	}
+	if n > 0 {
+		visit(0)
+	}
+}
+
+// computeFrontiers walks up from the predecessors of every join point to its
+// immediate dominator, adding the join point to each frontier on the way.
+func (d *Dominators) computeFrontiers(preds [][]int) {
+	n := len(d.ids)
+	d.frontier = make([][]int, n)
+	seen := make([]map[int]bool, n)
+	for v := 0; v < n; v++ {
+		// The entry behaves as if it had an extra predecessor outside the
+		// graph, so an edge back to it makes it a join point too.
+		if len(preds[v]) < 2 && v != 0 {
+			continue
+		}
+		for _, p := range preds[v] {
+			for runner := p; v == 0 || runner != d.idom[v]; runner = d.idom[runner] {
+				if seen[runner] == nil {
+					seen[runner] = make(map[int]bool)
+				}
+				if !seen[runner][v] {
+					seen[runner][v] = true
+					d.frontier[runner] = append(d.frontier[runner], v)
+				}
+				if runner == 0 {
+					break
+				}
+			}
+		}
+	}
+	for _, list := range d.frontier {
+		d.sortByID(list)
+	}
+}
+
+func (d *Dominators) sortByID(list []int) {
+	sort.Slice(list, func(i, j int) bool { return d.ids[list[i]] < d.ids[list[j]] })
+}
+
+func (d *Dominators) names(list []int) []string {
+	names := make([]string, len(list))
+	for i, v := range list {
+		names[i] = d.ids[v]
+	}
+	return names
+}
@@ -0,0 +1,162 @@
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// loopCFG is a control-flow graph with a diamond inside a loop:
+// entry -> a -> {b, c} -> d -> {a, exit}. orphan is unreachable.
+func loopCFG() *Graph {
+	return buildDAG([][3]any{
+		{"entry", "a", 1}, {"a", "b", 1}, {"a", "c", 1},
+		{"b", "d", 1}, {"c", "d", 1}, {"d", "a", 1},
+		{"d", "exit", 1}, {"orphan", "a", 1},
+	})
+}
+
+func TestDominators(t *testing.T) {
+	dom, ok := loopCFG().Dominators("entry")
+	if !ok || dom.Entry() != "entry" {
+		t.Fatal("expected dominators to be computed")
+	}
+
+	idoms := map[string]string{"a": "entry", "b": "a", "c": "a", "d": "a", "exit": "d"}
+	for id, expected := range idoms {
+		if got, ok := dom.ImmediateDominator(id); !ok || got != expected {
+			t.Fatalf("expected idom(%s) = %s, got %q", id, expected, got)
+		}
+	}
+	if _, ok := dom.ImmediateDominator("entry"); ok {
+		t.Fatal("expected the entry to have no immediate dominator")
+	}
+	if _, ok := dom.ImmediateDominator("orphan"); ok || dom.Reachable("orphan") {
+		t.Fatal("expected unreachable vertices to be left out")
+	}
+
+	if !dom.Dominates("a", "exit") || !dom.Dominates("d", "d") || dom.Dominates("b", "d") || dom.Dominates("orphan", "a") {
+		t.Fatal("unexpected dominance relation")
+	}
+	if children := dom.Children("a"); !reflect.DeepEqual(children, []string{"b", "c", "d"}) {
+		t.Fatalf("expected children [b c d], got %v", children)
+	}
+	if tree := dom.Tree(); len(tree.GetVertices()) != 6 || len(tree.GetEdges()) != 5 || !tree.HasEdge("d", "exit") {
+		t.Fatal("expected a dominator tree over the 6 reachable vertices")
+	}
+
+	frontiers := map[string][]string{
+		"entry": {}, "a": {"a"}, "b": {"d"}, "c": {"d"}, "d": {"a"}, "exit": {},
+	}
+	if got := dom.Frontiers(); !reflect.DeepEqual(got, frontiers) {
+		t.Fatalf("expected frontiers %v, got %v", frontiers, got)
+	}
+
+	if _, ok := NewGraph(false).Dominators("a"); ok {
+		t.Fatal("expected undirected graphs to be rejected")
+	}
+	if _, ok := loopCFG().Dominators("missing"); ok {
+		t.Fatal("expected unknown entries to be rejected")
+	}
+}
+
+func TestDominatorsLoopToEntry(t *testing.T) {
+	g := buildDAG([][3]any{{"entry", "body", 1}, {"body", "entry", 1}})
+	dom, _ := g.Dominators("entry")
+	if frontier := dom.Frontier("entry"); !reflect.DeepEqual(frontier, []string{"entry"}) {
+		t.Fatalf("expected a back edge to the entry to put it in its own frontier, got %v", frontier)
+	}
+	if frontier := dom.Frontier("body"); !reflect.DeepEqual(frontier, []string{"entry"}) {
+		t.Fatalf("expected frontier [entry] for body, got %v", frontier)
+	}
+}
+
+func TestPostDominators(t *testing.T) {
+	pdom, ok := loopCFG().PostDominators("exit")
+	if !ok {
+		t.Fatal("expected post-dominators to be computed")
+	}
+
+	ipdoms := map[string]string{"entry": "a", "orphan": "a", "a": "d", "b": "d", "c": "d", "d": "exit"}
+	for id, expected := range ipdoms {
+		if got, ok := pdom.ImmediateDominator(id); !ok || got != expected {
+			t.Fatalf("expected ipdom(%s) = %s, got %q", id, expected, got)
+		}
+	}
+
+	// Post-dominance frontiers are control dependences: b and c depend on the
+	// branch in a, and a and d on the loop test in d.
+	for id, expected := range map[string][]string{"b": {"a"}, "c": {"a"}, "a": {"d"}, "d": {"d"}, "entry": {}} {
+		if got := pdom.Frontier(id); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("expected control dependences %v for %s, got %v", expected, id, got)
+		}
+	}
+}
+
+func TestDominatorsMatchDefinition(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	for trial := 0; trial < 50; trial++ {
+		g := NewGraph(true)
+		n := 3 + r.Intn(10)
+		for i := 0; i < n; i++ {
+			g.AddVertex(strconv.Itoa(i))
+		}
+		for i := 0; i < 2*n; i++ {
+			g.AddEdge(strconv.Itoa(r.Intn(n)), strconv.Itoa(r.Intn(n)), 1)
+		}
+
+		dom, _ := g.Dominators("0")
+		reachable := reachableAvoiding(g, "0", "")
+		for a := range reachable {
+			without := reachableAvoiding(g, "0", a)
+			for b := range reachable {
+				expected := a == b || !without[b]
+				if dom.Dominates(a, b) != expected {
+					t.Fatalf("trial %d: expected Dominates(%s, %s) = %t", trial, a, b, expected)
+				}
+			}
+		}
+
+		for a := range reachable {
+			expected := []string{}
+			for _, b := range g.sortedVertexIDs() {
+				if !reachable[b] || (a != b && dom.Dominates(a, b)) {
+					continue
+				}
+				for _, edge := range g.GetEdges() {
+					if edge.To().ID() == b && reachable[edge.From().ID()] && dom.Dominates(a, edge.From().ID()) {
+						expected = append(expected, b)
+						break
+					}
+				}
+			}
+			if got := dom.Frontier(a); !reflect.DeepEqual(got, expected) {
+				t.Fatalf("trial %d: expected frontier %v for %s, got %v", trial, expected, a, got)
+			}
+		}
+	}
+}
+
+// reachableAvoiding returns the vertices reachable from start without passing
+// through avoid. When avoid is start, nothing is reachable.
+func reachableAvoiding(g *Graph, start, avoid string) map[string]bool {
+	reached := map[string]bool{}
+	if start == avoid {
+		return reached
+	}
+	reached[start] = true
+	stack := []string{start}
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		neighbors, _ := g.Neighbors(u)
+		for _, v := range neighbors {
+			if v.ID() != avoid && !reached[v.ID()] {
+				reached[v.ID()] = true
+				stack = append(stack, v.ID())
+			}
+		}
+	}
+	return reached
+}
@@ -0,0 +1,14 @@
+package graph
+
+import "errors"
+
+// Errors returned by the error-reporting variants of the path algorithms.
+// Use errors.Is to test for them.
+var (
+	ErrVertexNotFound = errors.New("graph: vertex not found")
+	ErrNoPath         = errors.New("graph: no path between vertices")
+	ErrNegativeWeight = errors.New("graph: graph contains negative-weight edges")
+	ErrNilHeuristic   = errors.New("graph: heuristic is nil")
+	ErrCostOverflow   = errors.New("graph: path cost overflows int")
+	ErrBudgetExceeded = errors.New("graph: search expansion budget exceeded")
+)
@@ -0,0 +1,496 @@
+package graph
+
+// exactMatchingLimit is the largest number of odd-degree vertices for which
+// ChinesePostman computes an exact minimum-weight pairing.
+const exactMatchingLimit = 20
+
+// HasEulerianCircuit reports whether the graph has a closed walk that uses
+// every edge exactly once. Isolated vertices are ignored, and a graph without
+// edges trivially has an (empty) circuit.
+func (g *Graph) HasEulerianCircuit() bool {
+	_, ok := newEulerGraph(g, g.sortedVertexIDs()).start(true)
+	return ok
+}
+
+// HasEulerianPath reports whether the graph has a walk, not necessarily
+// closed, that uses every edge exactly once. Every graph with an Eulerian
+// circuit also has an Eulerian path.
+func (g *Graph) HasEulerianPath() bool {
+	_, ok := newEulerGraph(g, g.sortedVertexIDs()).start(false)
+	return ok
+}
+
+// EulerianCircuit returns a closed walk that uses every edge exactly once,
+// built with Hierholzer's algorithm. Edges are oriented in the direction they
+// are traversed. It returns (nil, false) when no circuit exists.
+func (g *Graph) EulerianCircuit() ([]*Edge, bool) {
+	return g.eulerianWalk(true)
+}
+
+// EulerianPath returns a walk that uses every edge exactly once, built with
+// Hierholzer's algorithm. A circuit is returned when one exists; otherwise the
+// walk starts at the vertex with an odd degree (undirected) or with one more
+// outbound than inbound edge (directed). It returns (nil, false) when no such
+// walk exists.
+func (g *Graph) EulerianPath() ([]*Edge, bool) {
+	if walk, ok := g.eulerianWalk(true); ok {
+		return walk, true
+	}
+	return g.eulerianWalk(false)
+}
+
+// ChinesePostman returns the cheapest closed walk that uses every edge at
+// least once, together with its total weight.
+//
+// When the graph has no Eulerian circuit, edges along shortest paths are
+// duplicated until it does. Undirected graphs pair odd-degree vertices with a
+// minimum-weight matching, which is exact for up to 20 odd vertices and greedy
+// (closest pair first) beyond that. Directed graphs balance in-degree and
+// out-degree with a minimum-cost transportation between surplus and deficit
+// vertices.
+//
+// It returns (nil, 0, false) when the graph has negative-weight edges or when
+// its edges are not all mutually reachable.
+func (g *Graph) ChinesePostman() ([]*Edge, int, bool) {
+	if g.hasNegativeWeightEdge() {
+		return nil, 0, false
+	}
+
+	ids := g.sortedVertexIDs()
+	m := newEulerGraph(g, ids)
+	if len(m.from) == 0 {
+		return []*Edge{}, 0, true
+	}
+
+	adj := g.indexedAdjacency(ids)
+	var balanced bool
+	if g.directed {
+		balanced = m.balanceDegrees(adj)
+	} else {
+		balanced = m.pairOddVertices(adj)
+	}
+	if !balanced {
+		return nil, 0, false
+	}
+
+	start := 0
+	for len(m.incident[start]) == 0 {
+		start++
+	}
+	walk := m.hierholzer(start)
+	if len(walk) != len(m.from) {
+		return nil, 0, false
+	}
+
+	edges := g.walkEdges(ids, walk)
+	cost := 0
+	for _, edge := range edges {
+		var ok bool
+		if cost, ok = addCost(cost, edge.weight); !ok {
+			return nil, 0, false
+		}
+	}
+	return edges, cost, true
+}
+
+func (g *Graph) eulerianWalk(closed bool) ([]*Edge, bool) {
+	ids := g.sortedVertexIDs()
+	m := newEulerGraph(g, ids)
+	start, ok := m.start(closed)
+	if !ok {
+		return nil, false
+	}
+	if start < 0 {
+		return []*Edge{}, true
+	}
+	return g.walkEdges(ids, m.hierholzer(start)), true
+}
+
+// walkEdges converts a walk of (from, to) index pairs into graph edges.
+func (g *Graph) walkEdges(ids []string, walk [][2]int) []*Edge {
+	edges := make([]*Edge, len(walk))
+	for i, step := range walk {
+		edges[i] = g.vertices[ids[step[0]]].edges[ids[step[1]]]
+	}
+	return edges
+}
+
+// eulerGraph is an integer-indexed multigraph used by Hierholzer's algorithm.
+// It allows the same edge to appear several times, which the Chinese Postman
+// solver relies on when duplicating edges.
+type eulerGraph struct {
+	directed bool
+	from     []int
+	to       []int
+	incident [][]int // Edge IDs leaving (directed) or touching (undirected) each vertex
+}
+
+func newEulerGraph(g *Graph, ids []string) *eulerGraph {
+	m := &eulerGraph{directed: g.directed, incident: make([][]int, len(ids))}
+	for u, edges := range g.indexedAdjacency(ids) {
+		for _, edge := range edges {
+			if g.directed || u < edge.to {
+				m.addEdge(u, edge.to)
+			}
+		}
+	}
+	return m
+}
+
+func (m *eulerGraph) addEdge(u, v int) {
+	id := len(m.from)
+	m.from = append(m.from, u)
+	m.to = append(m.to, v)
+	m.incident[u] = append(m.incident[u], id)
+	if !m.directed {
+		m.incident[v] = append(m.incident[v], id)
+	}
+}
+
+// addPath adds one edge for every consecutive pair of vertices in path.
+func (m *eulerGraph) addPath(path []int) {
+	for i := 0; i+1 < len(path); i++ {
+		m.addEdge(path[i], path[i+1])
+	}
+}
+
+// degreeBalance returns out-degree minus in-degree for every vertex of a
+// directed multigraph.
+func (m *eulerGraph) degreeBalance() []int {
+	balance := make([]int, len(m.incident))
+	for id := range m.from {
+		balance[m.from[id]]++
+		balance[m.to[id]]--
+	}
+	return balance
+}
+
+// start returns the vertex an Eulerian walk must start from, or -1 when there
+// are no edges. ok is false when no such walk exists. When closed is true the
+// walk must be a circuit.
+func (m *eulerGraph) start(closed bool) (int, bool) {
+	if len(m.from) == 0 {
+		return -1, true
+	}
+	if !m.edgesConnected() {
+		return 0, false
+	}
+
+	first := -1
+	for v := range m.incident {
+		if len(m.incident[v]) > 0 {
+			first = v
+			break
+		}
+	}
+
+	if !m.directed {
+		var odd []int
+		for v, incident := range m.incident {
+			if len(incident)%2 == 1 {
+				odd = append(odd, v)
+			}
+		}
+		switch {
+		case len(odd) == 0:
+			return first, true
+		case len(odd) == 2 && !closed:
+			return odd[0], true
+		default:
+			return 0, false
+		}
+	}
+
+	start, end := -1, -1
+	for v, balance := range m.degreeBalance() {
+		switch {
+		case balance == 0:
+		case balance == 1 && start < 0 && !closed:
+			start = v
+		case balance == -1 && end < 0 && !closed:
+			end = v
+		default:
+			return 0, false
+		}
+	}
+	if (start < 0) != (end < 0) {
+		return 0, false
+	}
+	if start < 0 {
+		return first, true
+	}
+	return start, true
+}
+
+// edgesConnected reports whether all vertices with edges belong to the same
+// component when edge direction is ignored.
+func (m *eulerGraph) edgesConnected() bool {
+	neighbors := make([][]int, len(m.incident))
+	for id := range m.from {
+		neighbors[m.from[id]] = append(neighbors[m.from[id]], m.to[id])
+		neighbors[m.to[id]] = append(neighbors[m.to[id]], m.from[id])
+	}
+
+	root := m.from[0]
+	visited := make([]bool, len(neighbors))
+	visited[root] = true
+	queue := []int{root}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, u := range neighbors[v] {
+			if !visited[u] {
+				visited[u] = true
+				queue = append(queue, u)
+			}
+		}
+	}
+
+	for v := range neighbors {
+		if len(neighbors[v]) > 0 && !visited[v] {
+			return false
+		}
+	}
+	return true
+}
+
+// hierholzer returns the Eulerian walk from start as (from, to) index pairs.
+// Only edges reachable from start are included.
+func (m *eulerGraph) hierholzer(start int) [][2]int {
+	type frame struct {
+		vertex int
+		from   int
+		edge   int
+	}
+
+	used := make([]bool, len(m.from))
+	next := make([]int, len(m.incident))
+	stack := []frame{{vertex: start, from: -1, edge: -1}}
+	walk := make([][2]int, 0, len(m.from))
+
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+		v := top.vertex
+
+		advanced := false
+		for next[v] < len(m.incident[v]) {
+			id := m.incident[v][next[v]]
+			next[v]++
+			if used[id] {
+				continue
+			}
+			used[id] = true
+
+			other := m.to[id]
+			if other == v {
+				other = m.from[id]
+			}
+			stack = append(stack, frame{vertex: other, from: v, edge: id})
+			advanced = true
+			break
+		}
+
+		if !advanced {
+			stack = stack[:len(stack)-1]
+			if top.edge >= 0 {
+				walk = append(walk, [2]int{top.from, v})
+			}
+		}
+	}
+
+	for i, j := 0, len(walk)-1; i < j; i, j = i+1, j-1 {
+		walk[i], walk[j] = walk[j], walk[i]
+	}
+	return walk
+}
+
+// pairOddVertices duplicates shortest paths between pairs of odd-degree
+// vertices so that every degree becomes even. It returns false when some odd
+// vertices cannot reach each other.
+func (m *eulerGraph) pairOddVertices(adj [][]indexedEdge) bool {
+	var odd []int
+	for v, incident := range m.incident {
+		if len(incident)%2 == 1 {
+			odd = append(odd, v)
+		}
+	}
+	if len(odd) == 0 {
+		return true
+	}
+
+	dist := make([][]int, len(odd))
+	prev := make([][]int, len(odd))
+	for i, v := range odd {
+		dist[i], prev[i] = dijkstraTree(adj, v)
+	}
+
+	cost := make([][]int, len(odd))
+	for i := range odd {
+		cost[i] = make([]int, len(odd))
+		for j, u := range odd {
+			cost[i][j] = dist[i][u]
+		}
+	}
+
+	var pairs [][2]int
+	if len(odd) <= exactMatchingLimit {
+		pairs = exactPerfectMatching(cost)
+	} else {
+		pairs = greedyPerfectMatching(cost)
+	}
+	if pairs == nil {
+		return false
+	}
+
+	for _, pair := range pairs {
+		m.addPath(treePath(prev[pair[0]], odd[pair[1]]))
+	}
+	return true
+}
+
+// balanceDegrees duplicates shortest paths from vertices with more inbound
+// than outbound edges to vertices with more outbound than inbound edges, with
+// the minimum total weight. It returns false when the surplus cannot be routed.
+func (m *eulerGraph) balanceDegrees(adj [][]indexedEdge) bool {
+	balance := m.degreeBalance()
+	var surplus, deficit []int
+	total := 0
+	for v := range balance {
+		switch {
+		case balance[v] < 0:
+			surplus = append(surplus, v)
+			total -= balance[v]
+		case balance[v] > 0:
+			deficit = append(deficit, v)
+		}
+	}
+	if total == 0 {
+		return true
+	}
+
+	source := 0
+	sink := 1 + len(surplus) + len(deficit)
+	network := newResidualNetwork(sink + 1)
+	for i, v := range surplus {
+		network.addArc(source, 1+i, -balance[v], 0)
+	}
+	for j, v := range deficit {
+		network.addArc(1+len(surplus)+j, sink, balance[v], 0)
+	}
+
+	prev := make([][]int, len(surplus))
+	arcs := make([][]int, len(surplus))
+	for i, v := range surplus {
+		var dist []int
+		dist, prev[i] = dijkstraTree(adj, v)
+		arcs[i] = make([]int, len(deficit))
+		for j, u := range deficit {
+			arcs[i][j] = -1
+			if dist[u] >= 0 {
+				arcs[i][j] = network.addArc(1+i, 1+len(surplus)+j, total, dist[u])
+			}
+		}
+	}
+
+	if flow, _, ok := network.minCostFlow(source, sink, total); !ok || flow < total {
+		return false
+	}
+
+	for i := range surplus {
+		for j, u := range deficit {
+			if arcs[i][j] < 0 {
+				continue
+			}
+			copies := total - network.arcs[1+i][arcs[i][j]].capacity
+			path := treePath(prev[i], u)
+			for c := 0; c < copies; c++ {
+				m.addPath(path)
+			}
+		}
+	}
+	return true
+}
+
+// exactPerfectMatching pairs up the indices of cost with minimum total cost
+// using dynamic programming over subsets. Negative costs mark unreachable
+// pairs. It returns nil when no perfect matching exists.
+func exactPerfectMatching(cost [][]int) [][2]int {
+	n := len(cost)
+	full := 1<<n - 1
+	best := make([]int, full+1)
+	choice := make([][2]int, full+1)
+	for mask := range best {
+		best[mask] = -1
+	}
+	best[0] = 0
+
+	for mask := 0; mask < full; mask++ {
+		if best[mask] < 0 {
+			continue
+		}
+
+		i := 0
+		for mask&(1<<i) != 0 {
+			i++
+		}
+		for j := i + 1; j < n; j++ {
+			if mask&(1<<j) != 0 || cost[i][j] < 0 {
+				continue
+			}
+			next := mask | 1<<i | 1<<j
+			candidate, ok := addCost(best[mask], cost[i][j])
+			if !ok {
+				continue
+			}
+			if best[next] < 0 || candidate < best[next] {
+				best[next] = candidate
+				choice[next] = [2]int{i, j}
+			}
+		}
+	}
+
+	if best[full] < 0 {
+		return nil
+	}
+
+	pairs := make([][2]int, 0, n/2)
+	for mask := full; mask != 0; {
+		pair := choice[mask]
+		pairs = append(pairs, pair)
+		mask &^= 1<<pair[0] | 1<<pair[1]
+	}
+	return pairs
+}
+
+// greedyPerfectMatching repeatedly pairs the two closest unmatched indices of
+// cost. Negative costs mark unreachable pairs. It returns nil when some index
+// cannot be paired.
+func greedyPerfectMatching(cost [][]int) [][2]int {
+	n := len(cost)
+	matched := make([]bool, n)
+	pairs := make([][2]int, 0, n/2)
+
+	for len(pairs) < n/2 {
+		best := [2]int{-1, -1}
+		for i := 0; i < n; i++ {
+			if matched[i] {
+				continue
+			}
+			for j := i + 1; j < n; j++ {
+				if matched[j] || cost[i][j] < 0 {
+					continue
+				}
+				if best[0] < 0 || cost[i][j] < cost[best[0]][best[1]] {
+					best = [2]int{i, j}
+				}
+			}
+		}
+		if best[0] < 0 {
+			return nil
+		}
+		matched[best[0]] = true
+		matched[best[1]] = true
+		pairs = append(pairs, best)
+	}
+	return pairs
+}
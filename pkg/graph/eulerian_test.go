@@ -0,0 +1,189 @@
+package graph
+
+import "testing"
+
+// checkWalk verifies that walk is a connected sequence of edges and returns
+// how many times each undirected or directed edge key was used.
+func checkWalk(t *testing.T, g *Graph, walk []*Edge) map[string]int {
+	t.Helper()
+
+	uses := make(map[string]int)
+	for i, edge := range walk {
+		if i > 0 && walk[i-1].To() != edge.From() {
+			t.Fatalf("walk is broken between %s->%s and %s->%s",
+				walk[i-1].From().ID(), walk[i-1].To().ID(), edge.From().ID(), edge.To().ID())
+		}
+		key := edge.From().ID() + "->" + edge.To().ID()
+		if !g.IsDirected() {
+			key = makeUndirectedEdgeKey(edge.From().ID(), edge.To().ID())
+		}
+		uses[key]++
+	}
+	return uses
+}
+
+func TestEulerianUndirected(t *testing.T) {
+	square := buildCycleGraph(4)
+	if !square.HasEulerianCircuit() || !square.HasEulerianPath() {
+		t.Fatal("expected cycle to have an Eulerian circuit and path")
+	}
+
+	circuit, ok := square.EulerianCircuit()
+	if !ok || len(circuit) != 4 {
+		t.Fatalf("expected circuit of 4 edges, got %d (ok=%t)", len(circuit), ok)
+	}
+	if circuit[0].From() != circuit[len(circuit)-1].To() {
+		t.Fatal("expected circuit to be closed")
+	}
+	for key, count := range checkWalk(t, square, circuit) {
+		if count != 1 {
+			t.Fatalf("expected edge %s to be used once, got %d", key, count)
+		}
+	}
+
+	// The "house" shape: a square with a roof has exactly two odd vertices.
+	house := buildCycleGraph(4)
+	house.AddEdge("A", "E", 1)
+	house.AddEdge("B", "E", 1)
+	house.AddEdge("A", "C", 1)
+	if house.HasEulerianCircuit() {
+		t.Fatal("expected house graph to have no Eulerian circuit")
+	}
+	if !house.HasEulerianPath() {
+		t.Fatal("expected house graph to have an Eulerian path")
+	}
+
+	path, ok := house.EulerianPath()
+	if !ok || len(path) != 7 {
+		t.Fatalf("expected path of 7 edges, got %d (ok=%t)", len(path), ok)
+	}
+	if path[0].From().ID() != "B" || path[len(path)-1].To().ID() != "C" {
+		t.Fatalf("expected path between odd vertices B and C, got %s..%s",
+			path[0].From().ID(), path[len(path)-1].To().ID())
+	}
+	checkWalk(t, house, path)
+
+	star := NewGraph(false)
+	star.AddEdge("Hub", "A", 1)
+	star.AddEdge("Hub", "B", 1)
+	star.AddEdge("Hub", "C", 1)
+	if star.HasEulerianPath() {
+		t.Fatal("expected star with four odd vertices to have no Eulerian path")
+	}
+	if _, ok := star.EulerianPath(); ok {
+		t.Fatal("expected EulerianPath to fail on star")
+	}
+}
+
+func TestEulerianDirected(t *testing.T) {
+	g := NewGraph(true)
+	g.AddEdge("A", "B", 1)
+	g.AddEdge("B", "C", 1)
+	g.AddEdge("C", "A", 1)
+	g.AddEdge("A", "D", 1)
+	g.AddEdge("D", "A", 1)
+
+	circuit, ok := g.EulerianCircuit()
+	if !ok || len(circuit) != 5 {
+		t.Fatalf("expected circuit of 5 edges, got %d (ok=%t)", len(circuit), ok)
+	}
+	checkWalk(t, g, circuit)
+
+	g.RemoveEdge("D", "A")
+	if g.HasEulerianCircuit() {
+		t.Fatal("expected unbalanced graph to have no circuit")
+	}
+	path, ok := g.EulerianPath()
+	if !ok || len(path) != 4 {
+		t.Fatalf("expected path of 4 edges, got %d (ok=%t)", len(path), ok)
+	}
+	if path[0].From().ID() != "A" || path[len(path)-1].To().ID() != "D" {
+		t.Fatalf("expected path from A to D, got %s..%s", path[0].From().ID(), path[len(path)-1].To().ID())
+	}
+
+	disconnected := NewGraph(true)
+	disconnected.AddEdge("A", "B", 1)
+	disconnected.AddEdge("B", "A", 1)
+	disconnected.AddEdge("C", "D", 1)
+	disconnected.AddEdge("D", "C", 1)
+	if disconnected.HasEulerianPath() {
+		t.Fatal("expected disconnected graph to have no Eulerian path")
+	}
+
+	empty := NewGraph(true)
+	empty.AddVertex("A")
+	if walk, ok := empty.EulerianCircuit(); !ok || len(walk) != 0 {
+		t.Fatal("expected graph without edges to have an empty circuit")
+	}
+}
+
+func TestChinesePostmanUndirected(t *testing.T) {
+	// A path A-B-C: every edge must be walked twice.
+	g := NewGraph(false)
+	g.AddEdge("A", "B", 3)
+	g.AddEdge("B", "C", 4)
+
+	route, cost, ok := g.ChinesePostman()
+	if !ok {
+		t.Fatal("expected ChinesePostman to succeed")
+	}
+	if cost != 14 || len(route) != 4 {
+		t.Fatalf("expected cost 14 over 4 edges, got cost %d over %d", cost, len(route))
+	}
+	if route[0].From() != route[len(route)-1].To() {
+		t.Fatal("expected closed route")
+	}
+
+	// Romania has 8 odd vertices; the route must cover every road.
+	romania := BuildRomaniaGraph()
+	route, cost, ok = romania.ChinesePostman()
+	if !ok {
+		t.Fatal("expected ChinesePostman to succeed on Romania")
+	}
+	uses := checkWalk(t, romania, route)
+	if len(uses) != len(romania.GetEdges()) {
+		t.Fatalf("expected every road to be covered, got %d of %d", len(uses), len(romania.GetEdges()))
+	}
+	total := 0
+	for _, edge := range romania.GetEdges() {
+		total += edge.Weight()
+	}
+	if cost < total {
+		t.Fatalf("expected route cost %d to be at least the total road length %d", cost, total)
+	}
+
+	eulerian := buildCycleGraph(5)
+	if _, cost, ok := eulerian.ChinesePostman(); !ok || cost != 5 {
+		t.Fatalf("expected Eulerian graph to need no duplicates, got cost %d", cost)
+	}
+}
+
+func TestChinesePostmanDirected(t *testing.T) {
+	g := NewGraph(true)
+	g.AddEdge("A", "B", 1)
+	g.AddEdge("B", "C", 1)
+	g.AddEdge("C", "A", 1)
+	g.AddEdge("A", "C", 5)
+
+	// A->C unbalances the cycle; returning from C to A costs 1 (C->A).
+	route, cost, ok := g.ChinesePostman()
+	if !ok {
+		t.Fatal("expected ChinesePostman to succeed")
+	}
+	if cost != 9 || len(route) != 5 {
+		t.Fatalf("expected cost 9 over 5 edges, got cost %d over %d", cost, len(route))
+	}
+	checkWalk(t, g, route)
+
+	oneWay := NewGraph(true)
+	oneWay.AddEdge("A", "B", 1)
+	if _, _, ok := oneWay.ChinesePostman(); ok {
+		t.Fatal("expected ChinesePostman to fail when B cannot return to A")
+	}
+
+	negative := NewGraph(false)
+	negative.AddEdge("A", "B", -1)
+	if _, _, ok := negative.ChinesePostman(); ok {
+		t.Fatal("expected ChinesePostman to fail with negative weights")
+	}
+}
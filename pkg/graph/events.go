@@ -0,0 +1,78 @@
+package graph
+
+// EdgeEvent describes a change to an edge. In an undirected graph a change
+// produces a single event, with From and To as passed to the call that made it.
+type EdgeEvent struct {
+	From string
+	To   string
+	// Weight is the weight after the change; for removals it is the weight
+	// the edge had.
+	Weight int
+	// OldWeight is the weight before the change. It is only set for weight
+	// changes.
+	OldWeight int
+}
+
+// edgeListeners holds the subscribers of one kind of edge event.
+type edgeListeners struct {
+	nextID    int
+	listeners []edgeListener
+}
+
+type edgeListener struct {
+	id int
+	fn func(EdgeEvent)
+}
+
+// graphEvents holds the edge event subscribers of a graph.
+type graphEvents struct {
+	added   edgeListeners
+	removed edgeListeners
+	changed edgeListeners
+}
+
+// OnEdgeAdded registers fn to be called after an edge is added by AddEdge.
+// Listeners run synchronously, in registration order, and must not modify
+// the graph. The returned function removes the listener.
+func (g *Graph) OnEdgeAdded(fn func(EdgeEvent)) func() {
+	return g.events.added.subscribe(fn)
+}
+
+// OnEdgeRemoved registers fn to be called after an edge is removed, either by
+// RemoveEdge or as part of RemoveVertex. The returned function removes the
+// listener.
+func (g *Graph) OnEdgeRemoved(fn func(EdgeEvent)) func() {
+	return g.events.removed.subscribe(fn)
+}
+
+// OnEdgeWeightChanged registers fn to be called after AddEdge gives an
+// existing edge a different weight. The returned function removes the
+// listener.
+func (g *Graph) OnEdgeWeightChanged(fn func(EdgeEvent)) func() {
+	return g.events.changed.subscribe(fn)
+}
+
+func (l *edgeListeners) subscribe(fn func(EdgeEvent)) func() {
+	if fn == nil {
+		return func() {}
+	}
+
+	id := l.nextID
+	l.nextID++
+	l.listeners = append(l.listeners, edgeListener{id: id, fn: fn})
+
+	return func() {
+		for i, listener := range l.listeners {
+			if listener.id == id {
+				l.listeners = append(l.listeners[:i:i], l.listeners[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func (l *edgeListeners) emit(event EdgeEvent) {
+	for _, listener := range l.listeners {
+		listener.fn(event)
+	}
+}
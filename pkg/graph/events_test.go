@@ -0,0 +1,71 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEdgeEvents(t *testing.T) {
+	g := NewGraph(true)
+	var added, removed, changed []EdgeEvent
+	g.OnEdgeAdded(func(e EdgeEvent) { added = append(added, e) })
+	g.OnEdgeRemoved(func(e EdgeEvent) { removed = append(removed, e) })
+	stop := g.OnEdgeWeightChanged(func(e EdgeEvent) { changed = append(changed, e) })
+
+	g.AddEdge("A", "B", 1)
+	g.AddEdge("A", "B", 1)
+	g.AddEdge("A", "B", 4)
+	if len(added) != 1 || added[0] != (EdgeEvent{From: "A", To: "B", Weight: 1}) {
+		t.Fatalf("expected one added event, got %v", added)
+	}
+	if len(changed) != 1 || changed[0] != (EdgeEvent{From: "A", To: "B", Weight: 4, OldWeight: 1}) {
+		t.Fatalf("expected one weight change from 1 to 4, got %v", changed)
+	}
+
+	stop()
+	g.AddEdge("A", "B", 5)
+	if len(changed) != 1 {
+		t.Fatal("expected no events after unsubscribing")
+	}
+
+	g.RemoveEdge("A", "B")
+	g.RemoveEdge("A", "B")
+	if len(removed) != 1 || removed[0].Weight != 5 {
+		t.Fatalf("expected one removal of weight 5, got %v", removed)
+	}
+
+	g.AddEdge("A", "C", 2)
+	g.AddEdge("D", "A", 3)
+	g.AddEdge("C", "D", 1)
+	removed = nil
+	g.RemoveVertex("A")
+	if len(removed) != 2 {
+		t.Fatalf("expected removal events for A->C and D->A, got %v", removed)
+	}
+	if removed[0].To != "C" || removed[1].From != "D" {
+		t.Fatalf("expected outbound edges reported before inbound ones, got %v", removed)
+	}
+	if g.HasEdge("D", "A") {
+		t.Fatal("expected incoming edge to be removed")
+	}
+}
+
+func TestEdgeEventsUndirected(t *testing.T) {
+	g := BuildRomaniaGraph()
+	var removed []string
+	g.OnEdgeRemoved(func(e EdgeEvent) { removed = append(removed, e.To) })
+
+	// Sibiu has four roads; each is reported once, in neighbour ID order.
+	g.RemoveVertex("Sibiu")
+	want := []string{"Arad", "Fagaras", "Oradea", "Rimnicu Vilcea"}
+	if !reflect.DeepEqual(removed, want) {
+		t.Fatalf("expected removal events towards %v, got %v", want, removed)
+	}
+
+	if g.OnEdgeAdded(nil) == nil {
+		t.Fatal("expected a callable unsubscribe for a nil listener")
+	}
+	if clone := g.Clone(); len(clone.events.removed.listeners) != 0 {
+		t.Fatal("expected clones not to inherit listeners")
+	}
+}
@@ -0,0 +1,253 @@
+package graph
+
+import "container/heap"
+
+// MinCostFlow sends demand units of flow from source to sink through a
+// directed graph at the lowest total cost. Edge weights are costs per unit of
+// flow and edge capacities, set with SetCapacity, bound how much each edge
+// carries; edges without a capacity are unbounded.
+//
+// It returns the flow on every edge that carries some, keyed by source and
+// then destination vertex, together with the total cost. It returns
+// (nil, 0, false) for undirected graphs, for unknown or equal source and sink,
+// for a negative demand, when the network cannot carry demand units, when a
+// negative-cost cycle with spare capacity is reachable from source, or when
+// the total cost, or a distance the search computes on the way, overflows int.
+//
+// Transportation and assignment problems reduce to MinCostFlow by adding a
+// super source with an edge to every supplier (capacity = supply, cost 0)
+// and a super sink with an edge from every consumer (capacity = demand).
+func (g *Graph) MinCostFlow(source, sink string, demand int) (map[string]map[string]int, int, bool) {
+	if !g.directed || source == sink || demand < 0 {
+		return nil, 0, false
+	}
+
+	ids := g.sortedVertexIDs()
+	index := indexVertexIDs(ids)
+	s, sourceOK := index[source]
+	t, sinkOK := index[sink]
+	if !sourceOK || !sinkOK {
+		return nil, 0, false
+	}
+
+	type arcRef struct {
+		from, arc int
+		edge      *Edge
+	}
+	network := newResidualNetwork(len(ids))
+	refs := make([]arcRef, 0)
+	for u, id := range ids {
+		for _, edge := range g.vertices[id].edges {
+			arc := network.addArc(u, index[edge.to.id], edge.capacity, edge.weight)
+			refs = append(refs, arcRef{from: u, arc: arc, edge: edge})
+		}
+	}
+
+	flow, cost, ok := network.minCostFlow(s, t, demand)
+	if !ok || flow < demand {
+		return nil, 0, false
+	}
+
+	flows := make(map[string]map[string]int)
+	for _, ref := range refs {
+		used := ref.edge.capacity - network.arcs[ref.from][ref.arc].capacity
+		if used == 0 {
+			continue
+		}
+		from, to := ref.edge.from.id, ref.edge.to.id
+		if flows[from] == nil {
+			flows[from] = make(map[string]int)
+		}
+		flows[from][to] = used
+	}
+	return flows, cost, true
+}
+
+// residualArc is an arc of a residual network. rev is the index of the paired
+// reverse arc in arcs[to].
+type residualArc struct {
+	to       int
+	rev      int
+	capacity int
+	cost     int
+}
+
+// residualNetwork is an integer-indexed flow network stored as residual arcs.
+type residualNetwork struct {
+	arcs [][]residualArc
+}
+
+func newResidualNetwork(n int) *residualNetwork {
+	return &residualNetwork{arcs: make([][]residualArc, n)}
+}
+
+// addArc adds an arc from u to v and its zero-capacity reverse arc.
+// It returns the index of the forward arc in arcs[u].
+func (n *residualNetwork) addArc(u, v, capacity, cost int) int {
+	n.arcs[u] = append(n.arcs[u], residualArc{to: v, rev: len(n.arcs[v]), capacity: capacity, cost: cost})
+	n.arcs[v] = append(n.arcs[v], residualArc{to: u, rev: len(n.arcs[u]) - 1, capacity: 0, cost: -cost})
+	return len(n.arcs[u]) - 1
+}
+
+// minCostFlow sends up to limit units from s to t along successively cheapest
+// augmenting paths. Bellman-Ford computes initial vertex potentials, so
+// negative arc costs are allowed; every later path is found with Dijkstra on
+// the reduced costs cost(u, v) + potential[u] - potential[v], which the
+// potentials keep non-negative.
+//
+// It returns the flow sent and its cost, and false if a negative-cost cycle
+// is reachable from s or the cost of the flow, a potential or a reduced cost
+// does not fit in an int.
+func (n *residualNetwork) minCostFlow(s, t, limit int) (int, int, bool) {
+	potential, ok := n.initialPotentials(s)
+	if !ok {
+		return 0, 0, false
+	}
+
+	flow, cost := 0, 0
+	overflow := false
+	size := len(n.arcs)
+	dist := make([]int, size)
+	reached := make([]bool, size)
+	settled := make([]bool, size)
+	prevVertex := make([]int, size)
+	prevArc := make([]int, size)
+
+	for flow < limit {
+		for i := range dist {
+			dist[i] = 0
+			reached[i] = false
+			settled[i] = false
+			prevVertex[i] = -1
+		}
+		reached[s] = true
+
+		pq := &indexQueue{{vertex: s, priority: 0}}
+		for pq.Len() > 0 {
+			current := heap.Pop(pq).(indexItem)
+			u := current.vertex
+			if settled[u] {
+				continue
+			}
+			settled[u] = true
+			if u == t {
+				break
+			}
+			for i, arc := range n.arcs[u] {
+				if arc.capacity <= 0 || settled[arc.to] {
+					continue
+				}
+				reduced, ok := reducedCost(arc.cost, potential[u], potential[arc.to])
+				if !ok {
+					return flow, 0, false
+				}
+				candidate, ok := addCost(dist[u], reduced)
+				if ok && (!reached[arc.to] || candidate < dist[arc.to]) {
+					reached[arc.to] = true
+					dist[arc.to] = candidate
+					prevVertex[arc.to] = u
+					prevArc[arc.to] = i
+					heap.Push(pq, indexItem{vertex: arc.to, priority: candidate})
+				}
+			}
+		}
+
+		if !settled[t] {
+			break
+		}
+
+		// Vertices not settled before t are at least dist[t] away, so capping
+		// their update at dist[t] keeps every reduced cost non-negative.
+		for v := range potential {
+			step := dist[t]
+			if settled[v] {
+				step = dist[v]
+			}
+			if potential[v], ok = addCost(potential[v], step); !ok {
+				return flow, 0, false
+			}
+		}
+
+		push := limit - flow
+		pathCost := 0
+		for v := t; v != s; v = prevVertex[v] {
+			arc := n.arcs[prevVertex[v]][prevArc[v]]
+			push = min(push, arc.capacity)
+			if !overflow {
+				pathCost, ok = addCost(pathCost, arc.cost)
+				overflow = !ok
+			}
+		}
+		for v := t; v != s; v = prevVertex[v] {
+			u := prevVertex[v]
+			arc := &n.arcs[u][prevArc[v]]
+			arc.capacity -= push
+			n.arcs[v][arc.rev].capacity = saturatingAdd(n.arcs[v][arc.rev].capacity, push)
+		}
+
+		flow += push
+		// Once the cost has overflowed it stays unknown, even if later
+		// negative-cost paths would bring the true total back into range.
+		if !overflow {
+			var pushCost int
+			if pushCost, ok = mulCost(push, pathCost); ok {
+				cost, ok = addCost(cost, pushCost)
+			}
+			overflow = !ok
+		}
+	}
+
+	if overflow {
+		return flow, 0, false
+	}
+	return flow, cost, true
+}
+
+// reducedCost returns cost + from - to, the cost of an arc relative to the
+// potentials of its ends, and false if it overflows int.
+func reducedCost(cost, from, to int) (int, bool) {
+	difference, ok := subCost(from, to)
+	if !ok {
+		return 0, false
+	}
+	return addCost(cost, difference)
+}
+
+// initialPotentials returns shortest-path distances from s over arcs with
+// spare capacity, computed with Bellman-Ford, and false if a negative cycle
+// is reachable. Vertices s cannot reach get potential 0; they stay
+// unreachable as flow is pushed, because new residual arcs only join
+// vertices on augmenting paths.
+func (n *residualNetwork) initialPotentials(s int) ([]int, bool) {
+	size := len(n.arcs)
+	dist := make([]int, size)
+	reached := make([]bool, size)
+	reached[s] = true
+
+	for round := 0; round <= size; round++ {
+		updated := false
+		for u := 0; u < size; u++ {
+			if !reached[u] {
+				continue
+			}
+			for _, arc := range n.arcs[u] {
+				if arc.capacity <= 0 {
+					continue
+				}
+				candidate, ok := addCost(dist[u], arc.cost)
+				if !ok {
+					continue
+				}
+				if !reached[arc.to] || candidate < dist[arc.to] {
+					reached[arc.to] = true
+					dist[arc.to] = candidate
+					updated = true
+				}
+			}
+		}
+		if !updated {
+			return dist, true
+		}
+	}
+	return nil, false
+}
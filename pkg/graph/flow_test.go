@@ -0,0 +1,224 @@
+package graph
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+// addArc adds a directed edge with a cost and a capacity.
+func addArc(g *Graph, from, to string, cost, capacity int) {
+	g.AddEdge(from, to, cost)
+	g.SetCapacity(from, to, capacity)
+}
+
+func TestMinCostFlow(t *testing.T) {
+	g := NewGraph(true)
+	addArc(g, "s", "a", 2, 4)
+	addArc(g, "s", "b", 2, 2)
+	addArc(g, "a", "b", 1, 2)
+	addArc(g, "a", "t", 3, 3)
+	addArc(g, "b", "t", 1, 5)
+
+	flows, cost, ok := g.MinCostFlow("s", "t", 5)
+	if !ok {
+		t.Fatal("expected demand 5 to be feasible")
+	}
+	// s-b-t carries 2 at cost 3, s-a-b-t 2 at cost 4, s-a-t 1 at cost 5.
+	if cost != 19 {
+		t.Fatalf("expected cost 19, got %d", cost)
+	}
+	checkFlow(t, g, flows, "s", "t", 5)
+
+	if _, _, ok := g.MinCostFlow("s", "t", 7); ok {
+		t.Fatal("expected demand above the max flow of 6 to fail")
+	}
+	if flows, cost, ok := g.MinCostFlow("s", "t", 0); !ok || cost != 0 || len(flows) != 0 {
+		t.Fatal("expected zero demand to need no flow")
+	}
+}
+
+func TestMinCostFlowNegativeCosts(t *testing.T) {
+	g := NewGraph(true)
+	addArc(g, "s", "a", 1, 2)
+	addArc(g, "a", "t", -5, 1)
+	addArc(g, "a", "b", 0, 2)
+	addArc(g, "b", "t", 1, 2)
+
+	if _, cost, ok := g.MinCostFlow("s", "t", 2); !ok || cost != 2-5+1 {
+		t.Fatalf("expected cost -2, got %d", cost)
+	}
+
+	addArc(g, "b", "a", -1, 1)
+	if _, _, ok := g.MinCostFlow("s", "t", 1); ok {
+		t.Fatal("expected a reachable negative cycle to fail")
+	}
+}
+
+func TestMinCostFlowRejects(t *testing.T) {
+	undirected := NewGraph(false)
+	undirected.AddEdge("s", "t", 1)
+	if _, _, ok := undirected.MinCostFlow("s", "t", 1); ok {
+		t.Fatal("expected undirected graphs to be rejected")
+	}
+
+	g := NewGraph(true)
+	g.AddEdge("s", "t", 3)
+	if _, _, ok := g.MinCostFlow("s", "x", 1); ok {
+		t.Fatal("expected unknown sink to be rejected")
+	}
+	if _, _, ok := g.MinCostFlow("s", "s", 1); ok {
+		t.Fatal("expected equal source and sink to be rejected")
+	}
+	if _, _, ok := g.MinCostFlow("s", "t", -1); ok {
+		t.Fatal("expected negative demand to be rejected")
+	}
+	if flows, cost, ok := g.MinCostFlow("s", "t", 1000); !ok || cost != 3000 || flows["s"]["t"] != 1000 {
+		t.Fatal("expected an edge without capacity to be unbounded")
+	}
+}
+
+func TestMinCostFlowCostOverflow(t *testing.T) {
+	g := NewGraph(true)
+	addArc(g, "s", "a", math.MinInt/2, 1)
+	addArc(g, "a", "b", math.MinInt/2, 1)
+	addArc(g, "b", "t", -10, 1)
+	if _, cost, ok := g.MinCostFlow("s", "t", 1); ok {
+		t.Fatalf("expected a cost below MinInt to be rejected, got %d", cost)
+	}
+
+	g.SetCapacity("s", "a", 2)
+	g.SetCapacity("a", "b", 2)
+	g.RemoveEdge("b", "t")
+	addArc(g, "b", "t", 0, 2)
+	if _, cost, ok := g.MinCostFlow("s", "t", 1); !ok || cost != math.MinInt {
+		t.Fatalf("expected a cost of exactly MinInt to fit, got %d", cost)
+	}
+	if _, _, ok := g.MinCostFlow("s", "t", 2); ok {
+		t.Fatal("expected a total cost below MinInt to be rejected")
+	}
+
+	expensive := NewGraph(true)
+	addArc(expensive, "s", "t", math.MaxInt/2, 3)
+	if _, _, ok := expensive.MinCostFlow("s", "t", 3); ok {
+		t.Fatal("expected a total cost above MaxInt to be rejected")
+	}
+}
+
+func TestReducedCostIsChecked(t *testing.T) {
+	// 0 - MinInt wraps around to MinInt with unchecked arithmetic.
+	if _, ok := reducedCost(math.MaxInt, 0, math.MinInt); ok {
+		t.Fatal("expected an overflowing reduced cost to be rejected")
+	}
+	if reduced, ok := reducedCost(-5, math.MaxInt, math.MaxInt-5); !ok || reduced != 0 {
+		t.Fatalf("expected reduced cost 0, got %d", reduced)
+	}
+}
+
+func TestTransportationProblem(t *testing.T) {
+	supply := map[string]int{"P1": 20, "P2": 30, "P3": 25}
+	demand := map[string]int{"C1": 10, "C2": 35, "C3": 30}
+	costs := map[string]map[string]int{
+		"P1": {"C1": 8, "C2": 6, "C3": 10},
+		"P2": {"C1": 9, "C2": 12, "C3": 13},
+		"P3": {"C1": 14, "C2": 9, "C3": 16},
+	}
+
+	g := NewGraph(true)
+	for plant, units := range supply {
+		addArc(g, "source", plant, 0, units)
+		for customer, cost := range costs[plant] {
+			g.AddEdge(plant, customer, cost)
+		}
+	}
+	for customer, units := range demand {
+		addArc(g, customer, "sink", 0, units)
+	}
+
+	flows, cost, ok := g.MinCostFlow("source", "sink", 75)
+	if !ok {
+		t.Fatal("expected balanced transportation problem to be feasible")
+	}
+	checkFlow(t, g, flows, "source", "sink", 75)
+	// P1 ships 10 to C2 and 10 to C3; P2 ships 10 to C1 and 20 to C3;
+	// P3 ships 25 to C2.
+	if want := 10*6 + 10*10 + 10*9 + 20*13 + 25*9; cost != want {
+		t.Fatalf("expected optimal cost %d, got %d", want, cost)
+	}
+}
+
+func TestAssignmentProblem(t *testing.T) {
+	costs := [][]int{
+		{9, 2, 7, 8},
+		{6, 4, 3, 7},
+		{5, 8, 1, 8},
+		{7, 6, 9, 4},
+	}
+
+	g := NewGraph(true)
+	for i, row := range costs {
+		worker := "w" + strconv.Itoa(i)
+		addArc(g, "source", worker, 0, 1)
+		for j, cost := range row {
+			addArc(g, worker, "j"+strconv.Itoa(j), cost, 1)
+		}
+		addArc(g, "j"+strconv.Itoa(i), "sink", 0, 1)
+	}
+
+	flows, cost, ok := g.MinCostFlow("source", "sink", 4)
+	if !ok || cost != 13 {
+		t.Fatalf("expected optimal assignment cost 13, got %d", cost)
+	}
+	for i := range costs {
+		if len(flows["w"+strconv.Itoa(i)]) != 1 {
+			t.Fatalf("expected worker %d to get exactly one job", i)
+		}
+	}
+}
+
+func TestCapacityIsCopied(t *testing.T) {
+	g := NewGraph(false)
+	g.AddEdge("A", "B", 1)
+	if edge, _ := g.GetEdge("A", "B"); edge.Capacity() != UnboundedCapacity {
+		t.Fatal("expected new edges to be unbounded")
+	}
+	if !g.SetCapacity("A", "B", 3) || g.SetCapacity("A", "C", 1) || g.SetCapacity("A", "B", -1) {
+		t.Fatal("expected SetCapacity to accept only existing edges and non-negative capacities")
+	}
+	if edge, _ := g.GetEdge("B", "A"); edge.Capacity() != 3 {
+		t.Fatal("expected undirected reverse edge to share the capacity")
+	}
+
+	g.AddEdge("A", "B", 5)
+	if edge, _ := g.GetEdge("A", "B"); edge.Capacity() != 3 {
+		t.Fatal("expected reweighting to keep the capacity")
+	}
+	if edge, _ := g.Clone().GetEdge("B", "A"); edge.Capacity() != 3 {
+		t.Fatal("expected Clone to copy capacities")
+	}
+}
+
+// checkFlow verifies capacity limits and flow conservation, and that value
+// units leave source.
+func checkFlow(t *testing.T, g *Graph, flows map[string]map[string]int, source, sink string, value int) {
+	t.Helper()
+	balance := make(map[string]int)
+	for from, out := range flows {
+		for to, units := range out {
+			edge, ok := g.GetEdge(from, to)
+			if !ok || units < 0 || units > edge.Capacity() {
+				t.Fatalf("invalid flow %d on %s -> %s", units, from, to)
+			}
+			balance[from] -= units
+			balance[to] += units
+		}
+	}
+	for id, b := range balance {
+		switch {
+		case id == source && b != -value, id == sink && b != value:
+			t.Fatalf("expected %d units from %s to %s, got balance %d at %s", value, source, sink, b, id)
+		case id != source && id != sink && b != 0:
+			t.Fatalf("expected flow conservation at %s, got %d", id, b)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package graph
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// FrozenGraph is an immutable snapshot of a Graph in compressed sparse row
+// (CSR) form. Vertices are numbered 0..n-1 in ascending ID order, and the
+// outbound edges of vertex i occupy targets[offsets[i]:offsets[i+1]] and the
+// same range of weights, sorted by target. There is no per-edge allocation,
+// so large graphs take a fraction of the memory of a Graph and searches walk
+// contiguous arrays instead of maps.
+//
+// A FrozenGraph is safe for concurrent reads.
+type FrozenGraph struct {
+	directed bool
+	ids      []string
+	index    map[string]int
+	offsets  []int
+	targets  []int32
+	weights  []int
+	negative bool
+}
+
+// Freeze returns a FrozenGraph with the vertices and edges the graph has now.
+// Later changes to the graph do not affect the snapshot. An undirected edge
+// is stored once in each direction, as in the graph itself. Edge targets are
+// stored as int32, so Freeze returns (nil, false) for graphs with more than
+// math.MaxInt32 vertices.
+//
+// The edges are written straight into the CSR arrays, without an
+// intermediate adjacency list, so peak memory stays close to the size of the
+// snapshot itself.
+func (g *Graph) Freeze() (*FrozenGraph, bool) {
+	if len(g.vertices) > math.MaxInt32 {
+		return nil, false
+	}
+
+	ids := g.sortedVertexIDs()
+	f := &FrozenGraph{
+		directed: g.directed,
+		ids:      ids,
+		index:    indexVertexIDs(ids),
+		offsets:  make([]int, len(ids)+1),
+	}
+
+	edges := 0
+	for i, id := range ids {
+		f.offsets[i] = edges
+		edges += len(g.vertices[id].edges)
+	}
+	f.offsets[len(ids)] = edges
+
+	f.targets = make([]int32, edges)
+	f.weights = make([]int, edges)
+	for i, id := range ids {
+		lo, hi := f.offsets[i], f.offsets[i+1]
+		e := lo
+		for neighborID, edge := range g.vertices[id].edges {
+			f.targets[e] = int32(f.index[neighborID])
+			f.weights[e] = edge.weight
+			if edge.weight < 0 {
+				f.negative = true
+			}
+			e++
+		}
+		sort.Sort(csrRange{targets: f.targets[lo:hi], weights: f.weights[lo:hi]})
+	}
+	return f, true
+}
+
+// csrRange sorts the edges of one vertex by target, keeping the parallel
+// target and weight slices in step.
+type csrRange struct {
+	targets []int32
+	weights []int
+}
+
+func (r csrRange) Len() int { return len(r.targets) }
+
+func (r csrRange) Less(i, j int) bool { return r.targets[i] < r.targets[j] }
+
+func (r csrRange) Swap(i, j int) {
+	r.targets[i], r.targets[j] = r.targets[j], r.targets[i]
+	r.weights[i], r.weights[j] = r.weights[j], r.weights[i]
+}
+
+// IsDirected reports whether the snapshot was taken from a directed graph.
+func (f *FrozenGraph) IsDirected() bool {
+	return f.directed
+}
+
+// VertexCount returns the number of vertices.
+func (f *FrozenGraph) VertexCount() int {
+	return len(f.ids)
+}
+
+// EdgeCount returns the number of stored edges. Undirected edges count twice,
+// once per direction.
+func (f *FrozenGraph) EdgeCount() int {
+	return len(f.targets)
+}
+
+// Index returns the integer index of a vertex ID.
+func (f *FrozenGraph) Index(id string) (int, bool) {
+	i, ok := f.index[id]
+	return i, ok
+}
+
+// ID returns the vertex ID at index i, or "" if i is out of range.
+func (f *FrozenGraph) ID(i int) string {
+	if i < 0 || i >= len(f.ids) {
+		return ""
+	}
+	return f.ids[i]
+}
+
+// HasEdge reports whether an edge exists.
+func (f *FrozenGraph) HasEdge(from, to string) bool {
+	_, ok := f.EdgeWeight(from, to)
+	return ok
+}
+
+// EdgeWeight returns the weight of the edge from -> to. It binary searches
+// the sorted edge range of from.
+func (f *FrozenGraph) EdgeWeight(from, to string) (int, bool) {
+	u, ok := f.index[from]
+	if !ok {
+		return 0, false
+	}
+	v, ok := f.index[to]
+	if !ok {
+		return 0, false
+	}
+
+	lo, hi := f.offsets[u], f.offsets[u+1]
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if int(f.targets[mid]) < v {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	if lo < f.offsets[u+1] && int(f.targets[lo]) == v {
+		return f.weights[lo], true
+	}
+	return 0, false
+}
+
+// Neighbors returns the IDs of the outbound neighbours of a vertex, in
+// ascending order.
+func (f *FrozenGraph) Neighbors(id string) ([]string, bool) {
+	u, ok := f.index[id]
+	if !ok {
+		return nil, false
+	}
+
+	neighbors := make([]string, 0, f.offsets[u+1]-f.offsets[u])
+	for _, v := range f.targets[f.offsets[u]:f.offsets[u+1]] {
+		neighbors = append(neighbors, f.ids[v])
+	}
+	return neighbors, true
+}
+
+// NeighborsAt returns the outbound edges of vertex index i as parallel slices
+// of target indices and weights. The slices alias the snapshot's storage and
+// must not be modified. Both are nil if i is out of range.
+func (f *FrozenGraph) NeighborsAt(i int) ([]int32, []int) {
+	if i < 0 || i >= len(f.ids) {
+		return nil, nil
+	}
+	lo, hi := f.offsets[i], f.offsets[i+1]
+	return f.targets[lo:hi:hi], f.weights[lo:hi:hi]
+}
+
+// BFS returns the vertices reachable from start in breadth-first order,
+// visiting the neighbours of each vertex in ascending ID order. It returns
+// (nil, false) if start is not in the graph.
+func (f *FrozenGraph) BFS(start string) ([]string, bool) {
+	s, ok := f.index[start]
+	if !ok {
+		return nil, false
+	}
+
+	visited := make([]bool, len(f.ids))
+	visited[s] = true
+	queue := []int32{int32(s)}
+	order := []string{}
+	for head := 0; head < len(queue); head++ {
+		u := queue[head]
+		order = append(order, f.ids[u])
+		for _, v := range f.targets[f.offsets[u]:f.offsets[u+1]] {
+			if !visited[v] {
+				visited[v] = true
+				queue = append(queue, v)
+			}
+		}
+	}
+	return order, true
+}
+
+// ShortestPath returns the shortest path between start and goal using
+// Dijkstra. It has the same contract as Graph.ShortestPath, including
+// overflow-checked costs, and returns ([]string{}, 0, false) on failure.
+func (f *FrozenGraph) ShortestPath(start, goal string) ([]string, int, bool) {
+	return f.AStar(start, goal, func(current, goal int) int { return 0 })
+}
+
+// AStar returns the shortest path between start and goal using A*. The
+// heuristic receives vertex indices; use ID to map them back to vertex IDs.
+// It has the same contract as Graph.AStar and returns ([]string{}, 0, false)
+// when heuristic is nil, when no path exists, or when the graph has
+// negative-weight edges.
+func (f *FrozenGraph) AStar(start, goal string, heuristic func(current, goal int) int) ([]string, int, bool) {
+	s, startOK := f.index[start]
+	t, goalOK := f.index[goal]
+	if heuristic == nil || !startOK || !goalOK || f.negative {
+		return []string{}, 0, false
+	}
+	if s == t {
+		return []string{start}, 0, true
+	}
+
+	dist := make([]int, len(f.ids))
+	prev := make([]int, len(f.ids))
+	reached := make([]bool, len(f.ids))
+	for i := range prev {
+		prev[i] = -1
+	}
+	reached[s] = true
+
+	pq := &indexQueue{{vertex: s, priority: heuristic(s, t)}}
+	for pq.Len() > 0 {
+		current := heap.Pop(pq).(indexItem)
+		u := current.vertex
+		if current.priority > saturatingAdd(dist[u], heuristic(u, t)) {
+			continue
+		}
+		if u == t {
+			break
+		}
+
+		for e := f.offsets[u]; e < f.offsets[u+1]; e++ {
+			v := int(f.targets[e])
+			tentative, ok := addCost(dist[u], f.weights[e])
+			if ok && (!reached[v] || tentative < dist[v]) {
+				reached[v] = true
+				dist[v] = tentative
+				prev[v] = u
+				heap.Push(pq, indexItem{vertex: v, priority: saturatingAdd(tentative, heuristic(v, t))})
+			}
+		}
+	}
+
+	if !reached[t] {
+		return []string{}, 0, false
+	}
+
+	indices := treePath(prev, t)
+	path := make([]string, len(indices))
+	for i, v := range indices {
+		path[i] = f.ids[v]
+	}
+	return path, dist[t], true
+}
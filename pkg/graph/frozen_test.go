@@ -0,0 +1,135 @@
+package graph
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// freeze snapshots a graph known to be small enough to freeze.
+func freeze(g *Graph) *FrozenGraph {
+	f, _ := g.Freeze()
+	return f
+}
+
+func TestFreezeRomania(t *testing.T) {
+	g := BuildRomaniaGraph()
+	f, ok := g.Freeze()
+	if !ok {
+		t.Fatal("expected Freeze to succeed")
+	}
+
+	if f.IsDirected() || f.VertexCount() != 20 || f.EdgeCount() != 46 {
+		t.Fatalf("expected 20 vertices and 46 stored edges, got %d and %d", f.VertexCount(), f.EdgeCount())
+	}
+
+	path, cost, ok := f.ShortestPath("Arad", "Bucharest")
+	wantPath, wantCost, _ := g.ShortestPath("Arad", "Bucharest")
+	if !ok || cost != wantCost || !reflect.DeepEqual(path, wantPath) {
+		t.Fatalf("expected %v costing %d, got %v costing %d", wantPath, wantCost, path, cost)
+	}
+
+	straightLine := func(current, goal int) int {
+		return RomaniaBucharestHeuristic(&Vertex{id: f.ID(current)}, &Vertex{id: f.ID(goal)})
+	}
+	if _, cost, ok := f.AStar("Arad", "Bucharest", straightLine); !ok || cost != 418 {
+		t.Fatalf("expected A* cost 418, got %d", cost)
+	}
+
+	neighbors, ok := f.Neighbors("Arad")
+	if !ok || !reflect.DeepEqual(neighbors, []string{"Sibiu", "Timisoara", "Zerind"}) {
+		t.Fatalf("expected sorted neighbours of Arad, got %v", neighbors)
+	}
+	if w, ok := f.EdgeWeight("Sibiu", "Arad"); !ok || w != 140 {
+		t.Fatal("expected edge Sibiu-Arad with weight 140")
+	}
+	if f.HasEdge("Arad", "Bucharest") {
+		t.Fatal("expected no direct road from Arad to Bucharest")
+	}
+
+	order, ok := f.BFS("Arad")
+	if !ok || len(order) != 20 || order[0] != "Arad" || order[1] != "Sibiu" {
+		t.Fatalf("expected BFS to reach every city starting Arad, Sibiu, got %v", order)
+	}
+
+	g.AddEdge("Arad", "Bucharest", 1)
+	if f.HasEdge("Arad", "Bucharest") {
+		t.Fatal("expected the snapshot to ignore later changes")
+	}
+}
+
+func TestFreezeFailures(t *testing.T) {
+	g := NewGraph(true)
+	g.AddEdge("A", "B", 2)
+	g.AddVertex("C")
+	f, _ := g.Freeze()
+
+	if _, _, ok := f.ShortestPath("A", "C"); ok {
+		t.Fatal("expected unreachable goal to fail")
+	}
+	if _, _, ok := f.ShortestPath("B", "A"); ok {
+		t.Fatal("expected directed edges to be one-way")
+	}
+	if _, _, ok := f.AStar("A", "B", nil); ok {
+		t.Fatal("expected nil heuristic to fail")
+	}
+	if _, ok := f.Neighbors("Z"); ok {
+		t.Fatal("expected unknown vertex to fail")
+	}
+	if targets, weights := f.NeighborsAt(5); targets != nil || weights != nil {
+		t.Fatal("expected out-of-range index to return nil slices")
+	}
+	if path, cost, ok := f.ShortestPath("C", "C"); !ok || cost != 0 || len(path) != 1 {
+		t.Fatal("expected trivial path from C to itself")
+	}
+
+	g.AddEdge("B", "C", -1)
+	if _, _, ok := freeze(g).ShortestPath("A", "B"); ok {
+		t.Fatal("expected negative weights to fail")
+	}
+
+	huge := NewGraph(true)
+	huge.AddEdge("A", "B", math.MaxInt)
+	huge.AddEdge("B", "C", 1)
+	if _, _, ok := freeze(huge).ShortestPath("A", "C"); ok {
+		t.Fatal("expected overflowing path cost to fail")
+	}
+	if _, cost, ok := freeze(huge).ShortestPath("A", "B"); !ok || cost != math.MaxInt {
+		t.Fatalf("expected A-B costing exactly MaxInt, got %d", cost)
+	}
+}
+
+func TestFreezeMatchesGraph(t *testing.T) {
+	g, _ := ErdosRenyiGNP(300, 0.02, GeneratorOptions{Directed: true, Seed: 8, Weight: UniformWeight(1, 50)})
+	f, _ := g.Freeze()
+	for i := 0; i < 300; i += 7 {
+		goal := strconv.Itoa(299 - i)
+		_, want, wantOK := g.ShortestPath("0", goal)
+		_, got, gotOK := f.ShortestPath("0", goal)
+		if want != got || wantOK != gotOK {
+			t.Fatalf("goal %s: expected (%d, %v), got (%d, %v)", goal, want, wantOK, got, gotOK)
+		}
+	}
+	if f.EdgeCount() != len(g.GetEdges()) {
+		t.Fatalf("expected %d edges, got %d", len(g.GetEdges()), f.EdgeCount())
+	}
+}
+
+func BenchmarkFrozenShortestPathGrid(b *testing.B) {
+	g, _ := GridGraph(100, 100, GeneratorOptions{Seed: 1, Weight: UniformWeight(1, 10)})
+	f, _ := g.Freeze()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.ShortestPath("0,0", "99,99")
+	}
+}
+
+func BenchmarkFrozenShortestPathGNP(b *testing.B) {
+	g, _ := ErdosRenyiGNP(5000, 0.002, GeneratorOptions{Seed: 1, Weight: UniformWeight(1, 100)})
+	f, _ := g.Freeze()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.ShortestPath("0", "4999")
+	}
+}
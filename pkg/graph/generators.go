@@ -0,0 +1,486 @@
+package graph
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// WeightFunc returns the weight of a generated edge, drawing any randomness
+// from r so that generated graphs are reproducible.
+type WeightFunc func(r *rand.Rand) int
+
+// GeneratorOptions configures the graph generators.
+type GeneratorOptions struct {
+	Directed bool       // Generate a directed graph (ignored by generators that fix directedness)
+	Seed     int64      // Seed for the random source; equal seeds give equal graphs
+	Weight   WeightFunc // Edge weight distribution; nil means every edge weighs 1
+}
+
+// ConstantWeight gives every edge the weight w.
+func ConstantWeight(w int) WeightFunc {
+	return func(*rand.Rand) int { return w }
+}
+
+// UniformWeight draws weights uniformly from [min, max].
+// When max is less than min the bounds are swapped.
+func UniformWeight(min, max int) WeightFunc {
+	if max < min {
+		min, max = max, min
+	}
+	return func(r *rand.Rand) int {
+		return min + r.Intn(max-min+1)
+	}
+}
+
+// NormalWeight draws weights from a normal distribution with the given mean
+// and standard deviation, rounded to the nearest integer and clamped to min.
+func NormalWeight(mean, stddev float64, min int) WeightFunc {
+	return func(r *rand.Rand) int {
+		w := int(math.Round(r.NormFloat64()*stddev + mean))
+		if w < min {
+			return min
+		}
+		return w
+	}
+}
+
+// ExponentialWeight draws weights from an exponential distribution with the
+// given mean, rounded up so that every weight is at least 1.
+func ExponentialWeight(mean float64) WeightFunc {
+	return func(r *rand.Rand) int {
+		return int(math.Ceil(r.ExpFloat64() * mean))
+	}
+}
+
+// ErdosRenyiGNP generates a G(n, p) random graph on vertices "0" to "n-1":
+// every possible edge is present independently with probability p.
+// It runs in O(n + m) expected time by skipping absent edges geometrically.
+// It returns (nil, false) when n is negative or p is outside [0, 1].
+func ErdosRenyiGNP(n int, p float64, opts GeneratorOptions) (*Graph, bool) {
+	if n < 0 || p < 0 || p > 1 {
+		return nil, false
+	}
+
+	gen := newGenerator(numberedIDs(n), opts)
+	if p == 0 || n < 2 {
+		return gen.graph, true
+	}
+
+	pairs := n * (n - 1)
+	if !opts.Directed {
+		pairs /= 2
+	}
+	logQ := math.Log(1 - p)
+	for k := -1; ; {
+		skip := math.Floor(math.Log(1-gen.rand.Float64()) / logQ)
+		if float64(k)+1+skip >= float64(pairs) {
+			break
+		}
+		k += 1 + int(skip)
+		from, to := pairFromIndex(k, n, opts.Directed)
+		gen.addEdge(from, to)
+	}
+	return gen.graph, true
+}
+
+// ErdosRenyiGNM generates a G(n, m) random graph on vertices "0" to "n-1":
+// m distinct edges chosen uniformly among all possible edges.
+// It returns (nil, false) when n or m is negative or m exceeds the number of
+// possible edges.
+func ErdosRenyiGNM(n, m int, opts GeneratorOptions) (*Graph, bool) {
+	if n < 0 || m < 0 {
+		return nil, false
+	}
+	pairs := n * (n - 1)
+	if !opts.Directed {
+		pairs /= 2
+	}
+	if m > pairs {
+		return nil, false
+	}
+
+	gen := newGenerator(numberedIDs(n), opts)
+	if 2*m > pairs {
+		// Dense: shuffle the first m of all pair indices.
+		indices := make([]int, pairs)
+		for i := range indices {
+			indices[i] = i
+		}
+		for i := 0; i < m; i++ {
+			j := i + gen.rand.Intn(pairs-i)
+			indices[i], indices[j] = indices[j], indices[i]
+			from, to := pairFromIndex(indices[i], n, opts.Directed)
+			gen.addEdge(from, to)
+		}
+		return gen.graph, true
+	}
+
+	chosen := make(map[int]struct{}, m)
+	for len(chosen) < m {
+		k := gen.rand.Intn(pairs)
+		if _, ok := chosen[k]; ok {
+			continue
+		}
+		chosen[k] = struct{}{}
+		from, to := pairFromIndex(k, n, opts.Directed)
+		gen.addEdge(from, to)
+	}
+	return gen.graph, true
+}
+
+// BarabasiAlbert generates a scale-free graph by preferential attachment:
+// starting from a complete graph on m+1 vertices, each new vertex connects to
+// m distinct existing vertices chosen with probability proportional to their
+// degree. The result is always undirected.
+// It returns (nil, false) unless 1 <= m < n.
+func BarabasiAlbert(n, m int, opts GeneratorOptions) (*Graph, bool) {
+	if m < 1 || m >= n {
+		return nil, false
+	}
+
+	opts.Directed = false
+	gen := newGenerator(numberedIDs(n), opts)
+
+	// Every endpoint of every edge is listed once, so a uniform pick from
+	// endpoints is a pick proportional to degree.
+	var endpoints []int
+	for u := 0; u <= m; u++ {
+		for v := u + 1; v <= m; v++ {
+			gen.addEdge(u, v)
+			endpoints = append(endpoints, u, v)
+		}
+	}
+
+	for v := m + 1; v < n; v++ {
+		targets := make([]int, 0, m)
+		picked := make(map[int]struct{}, m)
+		for len(targets) < m {
+			u := endpoints[gen.rand.Intn(len(endpoints))]
+			if _, ok := picked[u]; ok {
+				continue
+			}
+			picked[u] = struct{}{}
+			targets = append(targets, u)
+		}
+		for _, u := range targets {
+			gen.addEdge(v, u)
+			endpoints = append(endpoints, v, u)
+		}
+	}
+	return gen.graph, true
+}
+
+// WattsStrogatz generates a small-world graph: a ring where every vertex is
+// connected to its k nearest neighbours (k/2 on each side), after which each
+// edge is rewired to a random endpoint with probability beta, avoiding
+// self-loops and duplicate edges. The result is always undirected.
+// It returns (nil, false) unless k is even, 0 <= k < n and beta is in [0, 1].
+func WattsStrogatz(n, k int, beta float64, opts GeneratorOptions) (*Graph, bool) {
+	if n < 0 || k < 0 || k%2 != 0 || (n > 0 && k >= n) || beta < 0 || beta > 1 {
+		return nil, false
+	}
+
+	opts.Directed = false
+	gen := newGenerator(numberedIDs(n), opts)
+	for j := 1; j <= k/2; j++ {
+		for u := 0; u < n; u++ {
+			gen.addEdge(u, (u+j)%n)
+		}
+	}
+
+	for j := 1; j <= k/2; j++ {
+		for u := 0; u < n; u++ {
+			if gen.rand.Float64() >= beta {
+				continue
+			}
+			// Leave the edge alone when u is already adjacent to everything.
+			if degree, _ := gen.graph.Degree(gen.ids[u]); degree >= n-1 {
+				continue
+			}
+			w := gen.rand.Intn(n)
+			for w == u || gen.graph.HasEdge(gen.ids[u], gen.ids[w]) {
+				w = gen.rand.Intn(n)
+			}
+			gen.graph.RemoveEdge(gen.ids[u], gen.ids[(u+j)%n])
+			gen.addEdge(u, w)
+		}
+	}
+	return gen.graph, true
+}
+
+// RandomDAG generates a directed acyclic graph: vertices are placed in a
+// random order and every edge from an earlier to a later vertex is present
+// independently with probability p. The result is always directed.
+// It returns (nil, false) when n is negative or p is outside [0, 1].
+func RandomDAG(n int, p float64, opts GeneratorOptions) (*Graph, bool) {
+	if n < 0 || p < 0 || p > 1 {
+		return nil, false
+	}
+
+	opts.Directed = true
+	gen := newGenerator(numberedIDs(n), opts)
+	order := gen.rand.Perm(n)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if gen.rand.Float64() < p {
+				gen.addEdge(order[i], order[j])
+			}
+		}
+	}
+	return gen.graph, true
+}
+
+// CompleteGraph generates a graph with an edge between every pair of the n
+// vertices (both directions when directed).
+func CompleteGraph(n int, opts GeneratorOptions) (*Graph, bool) {
+	if n < 0 {
+		return nil, false
+	}
+
+	gen := newGenerator(numberedIDs(n), opts)
+	for u := 0; u < n; u++ {
+		for v := 0; v < n; v++ {
+			if u != v && (opts.Directed || u < v) {
+				gen.addEdge(u, v)
+			}
+		}
+	}
+	return gen.graph, true
+}
+
+// CycleGraph generates the cycle 0 -> 1 -> ... -> n-1 -> 0.
+// It returns (nil, false) when n is less than 3.
+func CycleGraph(n int, opts GeneratorOptions) (*Graph, bool) {
+	if n < 3 {
+		return nil, false
+	}
+
+	gen := newGenerator(numberedIDs(n), opts)
+	for u := 0; u < n; u++ {
+		gen.addEdge(u, (u+1)%n)
+	}
+	return gen.graph, true
+}
+
+// StarGraph generates a star with hub "0" connected to the n-1 other
+// vertices (edges point away from the hub when directed).
+func StarGraph(n int, opts GeneratorOptions) (*Graph, bool) {
+	if n < 0 {
+		return nil, false
+	}
+
+	gen := newGenerator(numberedIDs(n), opts)
+	for v := 1; v < n; v++ {
+		gen.addEdge(0, v)
+	}
+	return gen.graph, true
+}
+
+// GridGraph generates a rows x cols grid with 4-neighbour connectivity.
+// Vertex IDs have the form "row,col" and can be read back with
+// GridCoordinates. Directed grids get an edge in each direction, each with
+// its own weight. It returns (nil, false) when rows or cols is negative.
+func GridGraph(rows, cols int, opts GeneratorOptions) (*Graph, bool) {
+	if rows < 0 || cols < 0 {
+		return nil, false
+	}
+
+	ids := make([]string, 0, rows*cols)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			ids = append(ids, strconv.Itoa(r)+","+strconv.Itoa(c))
+		}
+	}
+
+	gen := newGenerator(ids, opts)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			u := r*cols + c
+			if c+1 < cols {
+				gen.addEdge(u, u+1)
+				if opts.Directed {
+					gen.addEdge(u+1, u)
+				}
+			}
+			if r+1 < rows {
+				gen.addEdge(u, u+cols)
+				if opts.Directed {
+					gen.addEdge(u+cols, u)
+				}
+			}
+		}
+	}
+	return gen.graph, true
+}
+
+// GridCoordinates is a CoordinateExtractor for vertices created by GridGraph.
+// It returns the column as x and the row as y.
+func GridCoordinates(v *Vertex) (x, y int, ok bool) {
+	if v == nil {
+		return 0, 0, false
+	}
+	row, col, found := strings.Cut(v.id, ",")
+	if !found {
+		return 0, 0, false
+	}
+	r, errRow := strconv.Atoi(row)
+	c, errCol := strconv.Atoi(col)
+	if errRow != nil || errCol != nil {
+		return 0, 0, false
+	}
+	return c, r, true
+}
+
+// RandomTree generates a uniformly random labelled tree on n vertices from a
+// random Prüfer sequence, decoded in O(n). Directed trees point away from
+// vertex "0".
+func RandomTree(n int, opts GeneratorOptions) (*Graph, bool) {
+	if n < 0 {
+		return nil, false
+	}
+
+	gen := newGenerator(numberedIDs(n), opts)
+	if n < 2 {
+		return gen.graph, true
+	}
+
+	prufer := make([]int, n-2)
+	degree := make([]int, n)
+	for i := range degree {
+		degree[i] = 1
+	}
+	for i := range prufer {
+		prufer[i] = gen.rand.Intn(n)
+		degree[prufer[i]]++
+	}
+
+	neighbors := make([][]int, n)
+	link := func(u, v int) {
+		neighbors[u] = append(neighbors[u], v)
+		neighbors[v] = append(neighbors[v], u)
+	}
+	// Decode in linear time: ptr scans for the lowest unused leaf only
+	// forwards, and a vertex that becomes a leaf below ptr is used at once,
+	// since it is then the lowest leaf.
+	ptr := 0
+	for degree[ptr] != 1 {
+		ptr++
+	}
+	leaf := ptr
+	for _, v := range prufer {
+		link(leaf, v)
+		degree[leaf]--
+		degree[v]--
+		if degree[v] == 1 && v < ptr {
+			leaf = v
+			continue
+		}
+		ptr++
+		for degree[ptr] != 1 {
+			ptr++
+		}
+		leaf = ptr
+	}
+	link(leaf, n-1)
+
+	// Orient edges away from vertex 0 with a breadth-first traversal.
+	visited := make([]bool, n)
+	visited[0] = true
+	queue := []int{0}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		for _, child := range neighbors[parent] {
+			if !visited[child] {
+				visited[child] = true
+				gen.addEdge(parent, child)
+				queue = append(queue, child)
+			}
+		}
+	}
+	return gen.graph, true
+}
+
+// BalancedTree generates a complete tree of the given height in which every
+// internal vertex has branching children. The root is "0" and vertices are
+// numbered level by level. Directed trees point away from the root.
+// It returns (nil, false) when branching is less than 1 or height is negative.
+func BalancedTree(branching, height int, opts GeneratorOptions) (*Graph, bool) {
+	if branching < 1 || height < 0 {
+		return nil, false
+	}
+
+	n, level := 1, 1
+	for h := 0; h < height; h++ {
+		level *= branching
+		n += level
+	}
+
+	gen := newGenerator(numberedIDs(n), opts)
+	for child := 1; child < n; child++ {
+		gen.addEdge((child-1)/branching, child)
+	}
+	return gen.graph, true
+}
+
+// generator holds the state shared by the graph generators.
+type generator struct {
+	graph  *Graph
+	ids    []string
+	rand   *rand.Rand
+	weight WeightFunc
+}
+
+// newGenerator creates a generator whose graph already holds the given vertices.
+func newGenerator(ids []string, opts GeneratorOptions) *generator {
+	gen := &generator{
+		graph:  NewGraph(opts.Directed),
+		ids:    ids,
+		rand:   rand.New(rand.NewSource(opts.Seed)),
+		weight: opts.Weight,
+	}
+	if gen.weight == nil {
+		gen.weight = ConstantWeight(1)
+	}
+	for _, id := range ids {
+		gen.graph.AddVertex(id)
+	}
+	return gen
+}
+
+// numberedIDs returns the vertex IDs "0" to "n-1".
+func numberedIDs(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = strconv.Itoa(i)
+	}
+	return ids
+}
+
+func (gen *generator) addEdge(u, v int) {
+	gen.graph.AddEdge(gen.ids[u], gen.ids[v], gen.weight(gen.rand))
+}
+
+// pairFromIndex maps k in [0, pairs) to a distinct vertex pair: ordered pairs
+// for directed graphs, pairs with from > to for undirected graphs.
+func pairFromIndex(k, n int, directed bool) (int, int) {
+	if directed {
+		from, to := k/(n-1), k%(n-1)
+		if to >= from {
+			to++
+		}
+		return from, to
+	}
+
+	// Row v holds the v pairs (v, 0) .. (v, v-1), starting at index v(v-1)/2.
+	v := int((1 + math.Sqrt(float64(1+8*k))) / 2)
+	for v*(v-1)/2 > k {
+		v--
+	}
+	for (v+1)*v/2 <= k {
+		v++
+	}
+	return v, k - v*(v-1)/2
+}
@@ -0,0 +1,235 @@
+package graph
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+// sameGraph reports whether two graphs have the same vertices, edges and weights.
+func sameGraph(a, b *Graph) bool {
+	if a.IsDirected() != b.IsDirected() || len(a.GetVertices()) != len(b.GetVertices()) {
+		return false
+	}
+	if len(a.GetEdges()) != len(b.GetEdges()) {
+		return false
+	}
+	for _, edge := range a.GetEdges() {
+		other, ok := b.GetEdge(edge.From().ID(), edge.To().ID())
+		if !ok || other.Weight() != edge.Weight() {
+			return false
+		}
+	}
+	return true
+}
+
+func TestGeneratorsAreDeterministic(t *testing.T) {
+	opts := GeneratorOptions{Seed: 42, Weight: UniformWeight(1, 100)}
+	generators := map[string]func(GeneratorOptions) (*Graph, bool){
+		"gnp":    func(o GeneratorOptions) (*Graph, bool) { return ErdosRenyiGNP(50, 0.1, o) },
+		"gnm":    func(o GeneratorOptions) (*Graph, bool) { return ErdosRenyiGNM(50, 100, o) },
+		"ba":     func(o GeneratorOptions) (*Graph, bool) { return BarabasiAlbert(50, 2, o) },
+		"ws":     func(o GeneratorOptions) (*Graph, bool) { return WattsStrogatz(50, 4, 0.3, o) },
+		"dag":    func(o GeneratorOptions) (*Graph, bool) { return RandomDAG(30, 0.2, o) },
+		"tree":   func(o GeneratorOptions) (*Graph, bool) { return RandomTree(40, o) },
+		"grid":   func(o GeneratorOptions) (*Graph, bool) { return GridGraph(5, 6, o) },
+		"cycle":  func(o GeneratorOptions) (*Graph, bool) { return CycleGraph(10, o) },
+		"star":   func(o GeneratorOptions) (*Graph, bool) { return StarGraph(10, o) },
+		"kn":     func(o GeneratorOptions) (*Graph, bool) { return CompleteGraph(8, o) },
+		"kary":   func(o GeneratorOptions) (*Graph, bool) { return BalancedTree(3, 3, o) },
+		"gnp_dg": func(o GeneratorOptions) (*Graph, bool) { o.Directed = true; return ErdosRenyiGNP(30, 0.2, o) },
+	}
+
+	for name, generate := range generators {
+		t.Run(name, func(t *testing.T) {
+			first, ok := generate(opts)
+			if !ok {
+				t.Fatal("expected generator to succeed")
+			}
+			second, _ := generate(opts)
+			if !sameGraph(first, second) {
+				t.Fatal("expected equal seeds to produce equal graphs")
+			}
+		})
+	}
+
+	a, _ := ErdosRenyiGNP(50, 0.2, GeneratorOptions{Seed: 1})
+	b, _ := ErdosRenyiGNP(50, 0.2, GeneratorOptions{Seed: 2})
+	if sameGraph(a, b) {
+		t.Fatal("expected different seeds to produce different graphs")
+	}
+}
+
+func TestErdosRenyi(t *testing.T) {
+	complete, _ := ErdosRenyiGNP(10, 1, GeneratorOptions{})
+	if len(complete.GetEdges()) != 45 {
+		t.Fatalf("expected p=1 to give 45 edges, got %d", len(complete.GetEdges()))
+	}
+	empty, _ := ErdosRenyiGNP(10, 0, GeneratorOptions{})
+	if len(empty.GetEdges()) != 0 || len(empty.GetVertices()) != 10 {
+		t.Fatal("expected p=0 to give 10 isolated vertices")
+	}
+	directed, _ := ErdosRenyiGNP(10, 1, GeneratorOptions{Directed: true})
+	if len(directed.GetEdges()) != 90 {
+		t.Fatalf("expected directed p=1 to give 90 edges, got %d", len(directed.GetEdges()))
+	}
+
+	sparse, _ := ErdosRenyiGNP(2000, 0.01, GeneratorOptions{Seed: 7})
+	if edges := len(sparse.GetEdges()); edges < 18000 || edges > 22000 {
+		t.Fatalf("expected about 19990 edges, got %d", edges)
+	}
+
+	for _, m := range []int{0, 10, 40, 45} {
+		g, ok := ErdosRenyiGNM(10, m, GeneratorOptions{Seed: 3})
+		if !ok || len(g.GetEdges()) != m {
+			t.Fatalf("expected exactly %d edges", m)
+		}
+	}
+	if _, ok := ErdosRenyiGNM(10, 46, GeneratorOptions{}); ok {
+		t.Fatal("expected GNM to reject more edges than possible")
+	}
+	if _, ok := ErdosRenyiGNP(10, 1.5, GeneratorOptions{}); ok {
+		t.Fatal("expected GNP to reject p > 1")
+	}
+}
+
+func TestBarabasiAlbertAndWattsStrogatz(t *testing.T) {
+	ba, ok := BarabasiAlbert(100, 3, GeneratorOptions{Seed: 5, Directed: true})
+	if !ok || ba.IsDirected() {
+		t.Fatal("expected undirected Barabási–Albert graph")
+	}
+	// m+1 clique edges plus m edges for every later vertex.
+	if edges := len(ba.GetEdges()); edges != 6+96*3 {
+		t.Fatalf("expected %d edges, got %d", 6+96*3, edges)
+	}
+	if _, ok := BarabasiAlbert(3, 3, GeneratorOptions{}); ok {
+		t.Fatal("expected BarabasiAlbert to reject m >= n")
+	}
+
+	lattice, _ := WattsStrogatz(20, 4, 0, GeneratorOptions{})
+	for _, v := range lattice.GetVertices() {
+		if degree, _ := lattice.Degree(v.ID()); degree != 4 {
+			t.Fatalf("expected ring lattice degree 4, got %d", degree)
+		}
+	}
+	rewired, _ := WattsStrogatz(20, 4, 0.5, GeneratorOptions{Seed: 9})
+	if len(rewired.GetEdges()) != 40 {
+		t.Fatalf("expected rewiring to keep 40 edges, got %d", len(rewired.GetEdges()))
+	}
+	if _, ok := WattsStrogatz(20, 3, 0.1, GeneratorOptions{}); ok {
+		t.Fatal("expected WattsStrogatz to reject odd k")
+	}
+}
+
+func TestRandomDAGIsAcyclic(t *testing.T) {
+	g, ok := RandomDAG(40, 0.3, GeneratorOptions{Seed: 11})
+	if !ok || !g.IsDirected() {
+		t.Fatal("expected a directed graph")
+	}
+
+	state := make(map[string]int)
+	var visit func(id string) bool
+	visit = func(id string) bool {
+		state[id] = 1
+		neighbors, _ := g.Neighbors(id)
+		for _, next := range neighbors {
+			if state[next.ID()] == 1 || (state[next.ID()] == 0 && !visit(next.ID())) {
+				return false
+			}
+		}
+		state[id] = 2
+		return true
+	}
+	for _, v := range g.GetVertices() {
+		if state[v.ID()] == 0 && !visit(v.ID()) {
+			t.Fatal("expected RandomDAG to have no cycles")
+		}
+	}
+}
+
+func TestDeterministicFamilies(t *testing.T) {
+	grid, _ := GridGraph(3, 4, GeneratorOptions{})
+	if len(grid.GetVertices()) != 12 || len(grid.GetEdges()) != 17 {
+		t.Fatalf("expected 12 vertices and 17 edges, got %d and %d", len(grid.GetVertices()), len(grid.GetEdges()))
+	}
+	if _, cost, _ := grid.AStar("0,0", "2,3", ManhattanHeuristic(GridCoordinates)); cost != 5 {
+		t.Fatalf("expected grid corner distance 5, got %d", cost)
+	}
+	directedGrid, _ := GridGraph(3, 4, GeneratorOptions{Directed: true})
+	if len(directedGrid.GetEdges()) != 34 {
+		t.Fatalf("expected 34 directed grid edges, got %d", len(directedGrid.GetEdges()))
+	}
+
+	tree, _ := RandomTree(25, GeneratorOptions{Seed: 4})
+	if len(tree.GetEdges()) != 24 {
+		t.Fatalf("expected 24 tree edges, got %d", len(tree.GetEdges()))
+	}
+	for i := 1; i < 25; i++ {
+		if _, _, ok := tree.ShortestPath("0", strconv.Itoa(i)); !ok {
+			t.Fatalf("expected tree to be connected, %d unreachable", i)
+		}
+	}
+
+	balanced, _ := BalancedTree(2, 3, GeneratorOptions{Directed: true})
+	if len(balanced.GetVertices()) != 15 || !balanced.HasEdge("0", "1") || !balanced.HasEdge("6", "14") {
+		t.Fatal("expected binary tree of height 3 with root 0")
+	}
+
+	star, _ := StarGraph(5, GeneratorOptions{})
+	if degree, _ := star.Degree("0"); degree != 4 {
+		t.Fatalf("expected hub degree 4, got %d", degree)
+	}
+	cycle, _ := CycleGraph(6, GeneratorOptions{Directed: true})
+	if !cycle.HasEdge("5", "0") || len(cycle.GetEdges()) != 6 {
+		t.Fatal("expected directed cycle closing at 5 -> 0")
+	}
+	if _, ok := CycleGraph(2, GeneratorOptions{}); ok {
+		t.Fatal("expected CycleGraph to reject n < 3")
+	}
+}
+
+func TestWeightFuncs(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	uniform := UniformWeight(10, 5)
+	normal := NormalWeight(50, 10, 1)
+	exponential := ExponentialWeight(3)
+	for i := 0; i < 1000; i++ {
+		if w := uniform(r); w < 5 || w > 10 {
+			t.Fatalf("expected uniform weight in [5, 10], got %d", w)
+		}
+		if w := normal(r); w < 1 {
+			t.Fatalf("expected normal weight clamped to 1, got %d", w)
+		}
+		if w := exponential(r); w < 1 {
+			t.Fatalf("expected exponential weight at least 1, got %d", w)
+		}
+	}
+	if ConstantWeight(7)(r) != 7 {
+		t.Fatal("expected constant weight 7")
+	}
+}
+
+func BenchmarkShortestPathGrid(b *testing.B) {
+	g, _ := GridGraph(100, 100, GeneratorOptions{Seed: 1, Weight: UniformWeight(1, 10)})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.ShortestPath("0,0", "99,99")
+	}
+}
+
+func BenchmarkAStarGrid(b *testing.B) {
+	g, _ := GridGraph(100, 100, GeneratorOptions{Seed: 1, Weight: UniformWeight(1, 10)})
+	heuristic := ManhattanHeuristic(GridCoordinates)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.AStar("0,0", "99,99", heuristic)
+	}
+}
+
+func BenchmarkShortestPathGNP(b *testing.B) {
+	g, _ := ErdosRenyiGNP(5000, 0.002, GeneratorOptions{Seed: 1, Weight: UniformWeight(1, 100)})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.ShortestPath("0", "4999")
+	}
+}
@@ -1,10 +1,19 @@
 // Package graph provides data structures and algorithms for working with graphs.
 package graph
 
+import (
+	"math"
+	"sort"
+)
+
+// UnboundedCapacity is the capacity of an edge whose capacity was never set.
+const UnboundedCapacity = math.MaxInt
+
 // Graph represents a graph with a set of vertices.
 type Graph struct {
 	vertices map[string]*Vertex
 	directed bool
+	events   graphEvents
 }
 
 // Vertex represents a graph vertex.
@@ -15,9 +24,10 @@ type Vertex struct {
 
 // Edge represents a weighted connection between two vertices.
 type Edge struct {
-	from   *Vertex
-	to     *Vertex
-	weight int
+	from     *Vertex
+	to       *Vertex
+	weight   int
+	capacity int
 }
 
 // NewGraph creates a new graph.
@@ -54,6 +64,9 @@ func (g *Graph) AddVertex(id string) bool {
 
 // AddEdge adds or updates an edge in the graph.
 // In an undirected graph, the reverse edge is also created/updated.
+// New edges have UnboundedCapacity; updated edges keep their capacity.
+// It notifies OnEdgeAdded listeners for a new edge and OnEdgeWeightChanged
+// listeners when an existing edge gets a different weight.
 func (g *Graph) AddEdge(from, to string, weight int) bool {
 	if from == "" || to == "" || from == to {
 		return false
@@ -67,22 +80,53 @@ func (g *Graph) AddEdge(from, to string, weight int) bool {
 		g.AddVertex(to)
 	}
 
+	previous, existed := g.vertices[from].edges[to]
+	capacity := UnboundedCapacity
+	if existed {
+		capacity = previous.capacity
+	}
+
 	edge := &Edge{
-		from:   g.vertices[from],
-		to:     g.vertices[to],
-		weight: weight,
+		from:     g.vertices[from],
+		to:       g.vertices[to],
+		weight:   weight,
+		capacity: capacity,
 	}
 	g.vertices[from].edges[to] = edge
 
 	if !g.directed {
 		reverse := &Edge{
-			from:   g.vertices[to],
-			to:     g.vertices[from],
-			weight: weight,
+			from:     g.vertices[to],
+			to:       g.vertices[from],
+			weight:   weight,
+			capacity: capacity,
 		}
 		g.vertices[to].edges[from] = reverse
 	}
 
+	switch {
+	case !existed:
+		g.events.added.emit(EdgeEvent{From: from, To: to, Weight: weight})
+	case previous.weight != weight:
+		g.events.changed.emit(EdgeEvent{From: from, To: to, Weight: weight, OldWeight: previous.weight})
+	}
+
+	return true
+}
+
+// SetCapacity sets the capacity of an existing edge, the most flow it can
+// carry in MinCostFlow. In an undirected graph, the reverse edge gets the same
+// capacity. It returns false if the edge does not exist or capacity is negative.
+func (g *Graph) SetCapacity(from, to string, capacity int) bool {
+	edge, ok := g.GetEdge(from, to)
+	if !ok || capacity < 0 {
+		return false
+	}
+
+	edge.capacity = capacity
+	if !g.directed {
+		g.vertices[to].edges[from].capacity = capacity
+	}
 	return true
 }
 
@@ -134,28 +178,50 @@ func (g *Graph) GetEdges() []*Edge {
 }
 
 // RemoveVertex removes a vertex and all incident edges.
+// OnEdgeRemoved listeners are notified once per removed edge: first for the
+// outbound edges by target ID, then for the inbound ones by source ID.
 func (g *Graph) RemoveVertex(id string) bool {
-	if _, ok := g.vertices[id]; !ok {
+	removed, ok := g.vertices[id]
+	if !ok {
 		return false
 	}
 
-	for _, vertex := range g.vertices {
-		delete(vertex.edges, id)
+	var events []EdgeEvent
+	for _, edge := range removed.edges {
+		events = append(events, EdgeEvent{From: id, To: edge.to.id, Weight: edge.weight})
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].To < events[j].To })
+	outbound := len(events)
+	for otherID, vertex := range g.vertices {
+		if edge, ok := vertex.edges[id]; ok {
+			if g.directed {
+				events = append(events, EdgeEvent{From: otherID, To: id, Weight: edge.weight})
+			}
+			delete(vertex.edges, id)
+		}
 	}
+	inbound := events[outbound:]
+	sort.Slice(inbound, func(i, j int) bool { return inbound[i].From < inbound[j].From })
 
 	delete(g.vertices, id)
+
+	for _, event := range events {
+		g.events.removed.emit(event)
+	}
 	return true
 }
 
 // RemoveEdge removes an edge from the graph.
 // In an undirected graph, the reverse edge is also removed.
+// OnEdgeRemoved listeners are notified after the removal.
 func (g *Graph) RemoveEdge(from, to string) bool {
 	vertex, ok := g.GetVertex(from)
 	if !ok {
 		return false
 	}
 
-	if _, ok := vertex.edges[to]; !ok {
+	edge, ok := vertex.edges[to]
+	if !ok {
 		return false
 	}
 
@@ -167,6 +233,7 @@ func (g *Graph) RemoveEdge(from, to string) bool {
 		}
 	}
 
+	g.events.removed.emit(EdgeEvent{From: from, To: to, Weight: edge.weight})
 	return true
 }
 
@@ -243,6 +310,12 @@ func (e *Edge) Weight() int {
 	return e.weight
 }
 
+// Capacity returns the edge capacity, UnboundedCapacity unless it was set
+// with SetCapacity.
+func (e *Edge) Capacity() int {
+	return e.capacity
+}
+
 func makeUndirectedEdgeKey(a, b string) string {
 	if a < b {
 		return a + "|" + b
@@ -0,0 +1,159 @@
+package graph
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// indexedEdge is an outbound edge in an integer-indexed adjacency list.
+type indexedEdge struct {
+	to     int
+	weight int
+}
+
+// sortedVertexIDs returns all vertex IDs in ascending order.
+// Algorithms iterate in this order so that their results are deterministic.
+func (g *Graph) sortedVertexIDs() []string {
+	ids := make([]string, 0, len(g.vertices))
+	for id := range g.vertices {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// indexVertexIDs maps every ID to its position in ids.
+func indexVertexIDs(ids []string) map[string]int {
+	index := make(map[string]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+	}
+	return index
+}
+
+// indexedAdjacency builds an integer-indexed copy of the outbound edges.
+// Vertex i corresponds to ids[i]; each edge list is sorted by target index.
+func (g *Graph) indexedAdjacency(ids []string) [][]indexedEdge {
+	index := indexVertexIDs(ids)
+	adj := make([][]indexedEdge, len(ids))
+	for i, id := range ids {
+		vertex := g.vertices[id]
+		edges := make([]indexedEdge, 0, len(vertex.edges))
+		for neighborID, edge := range vertex.edges {
+			edges = append(edges, indexedEdge{to: index[neighborID], weight: edge.weight})
+		}
+		sort.Slice(edges, func(a, b int) bool { return edges[a].to < edges[b].to })
+		adj[i] = edges
+	}
+	return adj
+}
+
+// singleSourceDistances returns the distance from source to every vertex of adj,
+// or -1 for unreachable vertices. When weighted is false every edge counts as 1.
+// Weighted distances use Dijkstra and assume non-negative weights.
+func singleSourceDistances(adj [][]indexedEdge, source int, weighted bool) []int {
+	if weighted {
+		dist, _ := dijkstraTree(adj, source)
+		return dist
+	}
+
+	dist := make([]int, len(adj))
+	for i := range dist {
+		dist[i] = -1
+	}
+	dist[source] = 0
+
+	queue := []int{source}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, edge := range adj[u] {
+			if dist[edge.to] < 0 {
+				dist[edge.to] = dist[u] + 1
+				queue = append(queue, edge.to)
+			}
+		}
+	}
+	return dist
+}
+
+// dijkstraTree runs Dijkstra from source and returns the distance to every
+// vertex (-1 when unreachable) and the predecessor of every vertex on its
+// shortest path (-1 for source and unreachable vertices).
+// It assumes non-negative weights.
+func dijkstraTree(adj [][]indexedEdge, source int) ([]int, []int) {
+	dist := make([]int, len(adj))
+	prev := make([]int, len(adj))
+	for i := range dist {
+		dist[i] = -1
+		prev[i] = -1
+	}
+	dist[source] = 0
+
+	pq := &indexQueue{{vertex: source, priority: 0}}
+	for pq.Len() > 0 {
+		current := heap.Pop(pq).(indexItem)
+		if current.priority > dist[current.vertex] {
+			continue
+		}
+		for _, edge := range adj[current.vertex] {
+			tentative, ok := addCost(current.priority, edge.weight)
+			if !ok {
+				continue
+			}
+			if dist[edge.to] < 0 || tentative < dist[edge.to] {
+				dist[edge.to] = tentative
+				prev[edge.to] = current.vertex
+				heap.Push(pq, indexItem{vertex: edge.to, priority: tentative})
+			}
+		}
+	}
+	return dist, prev
+}
+
+// treePath walks prev back from target and returns the vertex sequence from
+// the root of the shortest-path tree to target.
+func treePath(prev []int, target int) []int {
+	path := []int{target}
+	for v := prev[target]; v >= 0; v = prev[v] {
+		path = append(path, v)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+type indexItem struct {
+	vertex   int
+	priority int
+}
+
+// indexQueue is a min-heap of integer-indexed vertices ordered by priority.
+// Ties are broken by vertex index to keep pop order deterministic.
+type indexQueue []indexItem
+
+func (pq indexQueue) Len() int { return len(pq) }
+
+func (pq indexQueue) Less(i, j int) bool {
+	if pq[i].priority != pq[j].priority {
+		return pq[i].priority < pq[j].priority
+	}
+	return pq[i].vertex < pq[j].vertex
+}
+
+func (pq indexQueue) Swap(i, j int) {
+	pq[i], pq[j] = pq[j], pq[i]
+}
+
+func (pq *indexQueue) Push(x any) {
+	*pq = append(*pq, x.(indexItem))
+}
+
+func (pq *indexQueue) Pop() any {
+	old := *pq
+	n := len(old)
+	item := old[n-1]
+	*pq = old[:n-1]
+	return item
+}
@@ -0,0 +1,268 @@
+package graph
+
+import "iter"
+
+// MatchOptions restricts which vertices and edges may correspond when
+// matching one graph against another.
+type MatchOptions struct {
+	// VertexMatch reports whether a pattern vertex may map to a target vertex.
+	// nil allows every pair.
+	VertexMatch func(pattern, target *Vertex) bool
+	// EdgeMatch reports whether a pattern edge may map to a target edge.
+	// nil allows every pair. In undirected graphs it is called for both
+	// directions of an edge.
+	EdgeMatch func(pattern, target *Edge) bool
+	// Induced requires the image of an embedding to be an induced subgraph:
+	// target vertices that are not adjacent in the pattern must not be
+	// adjacent in the target either. By default extra target edges are allowed.
+	Induced bool
+}
+
+// Isomorphic reports whether g1 and g2 are isomorphic, ignoring weights,
+// and returns a mapping from the vertex IDs of g1 to those of g2 when they
+// are. Graphs of different directedness are never isomorphic.
+func Isomorphic(g1, g2 *Graph) (map[string]string, bool) {
+	return IsomorphicWith(g1, g2, MatchOptions{})
+}
+
+// IsomorphicWith is Isomorphic with vertex and edge predicates, for example to
+// require equal weights. opts.Induced is ignored: an isomorphism is always
+// induced.
+func IsomorphicWith(g1, g2 *Graph, opts MatchOptions) (map[string]string, bool) {
+	if g1 == nil || g2 == nil || g1.directed != g2.directed {
+		return nil, false
+	}
+	if len(g1.vertices) != len(g2.vertices) || len(g1.GetEdges()) != len(g2.GetEdges()) {
+		return nil, false
+	}
+
+	// A structure-preserving bijection between graphs with equally many edges
+	// maps edges onto edges, so a plain embedding is already an isomorphism.
+	opts.Induced = false
+	for mapping := range g2.Embeddings(g1, opts) {
+		return mapping, true
+	}
+	return nil, false
+}
+
+// Embeddings returns every embedding of pattern into g: injective mappings
+// from pattern vertex IDs to vertex IDs of g such that every pattern edge
+// maps onto an edge of g, subject to opts. Weights are ignored unless
+// opts.EdgeMatch compares them.
+//
+// The search follows VF2: pattern vertices are matched in an order that
+// keeps them connected to already matched ones, candidates come from the
+// neighbourhoods of the matched vertices, and pairs are pruned by adjacency
+// with the partial mapping and by the number of unmatched neighbours.
+// Embeddings are yielded in a deterministic order, each as a fresh map, and
+// the search stops as soon as the caller stops ranging. If pattern and g
+// differ in directedness there are no embeddings.
+func (g *Graph) Embeddings(pattern *Graph, opts MatchOptions) iter.Seq[map[string]string] {
+	return func(yield func(map[string]string) bool) {
+		if pattern == nil || pattern.directed != g.directed || len(pattern.vertices) > len(g.vertices) {
+			return
+		}
+		if len(pattern.vertices) == 0 {
+			yield(map[string]string{})
+			return
+		}
+		newMatcher(pattern, g, opts).match(0, yield)
+	}
+}
+
+// matchGraph is an integer-indexed view of a graph for matching.
+type matchGraph struct {
+	ids      []string
+	vertices []*Vertex
+	out      [][]int
+	in       [][]int
+	edges    []map[int]*Edge // edges[u][v] is the edge u -> v
+}
+
+func newMatchGraph(g *Graph) *matchGraph {
+	ids := g.sortedVertexIDs()
+	m := &matchGraph{
+		ids:      ids,
+		vertices: make([]*Vertex, len(ids)),
+		out:      make([][]int, len(ids)),
+		in:       make([][]int, len(ids)),
+		edges:    make([]map[int]*Edge, len(ids)),
+	}
+	for u, list := range g.indexedAdjacency(ids) {
+		m.vertices[u] = g.vertices[ids[u]]
+		m.edges[u] = make(map[int]*Edge, len(list))
+		for _, edge := range list {
+			m.out[u] = append(m.out[u], edge.to)
+			m.in[edge.to] = append(m.in[edge.to], u)
+			m.edges[u][edge.to] = m.vertices[u].edges[ids[edge.to]]
+		}
+	}
+	return m
+}
+
+type matcher struct {
+	pattern *matchGraph
+	target  *matchGraph
+	opts    MatchOptions
+
+	order   []int // pattern vertices in matching order
+	core    []int // core[p] is the target matched to pattern vertex p, or -1
+	inverse []int // inverse[t] is the pattern vertex matched to target t, or -1
+	all     []int // every target vertex, for pattern vertices with no matched neighbour
+}
+
+func newMatcher(pattern, target *Graph, opts MatchOptions) *matcher {
+	m := &matcher{
+		pattern: newMatchGraph(pattern),
+		target:  newMatchGraph(target),
+		opts:    opts,
+	}
+	m.core = make([]int, len(m.pattern.ids))
+	for i := range m.core {
+		m.core[i] = -1
+	}
+	m.inverse = make([]int, len(m.target.ids))
+	m.all = make([]int, len(m.target.ids))
+	for i := range m.inverse {
+		m.inverse[i] = -1
+		m.all[i] = i
+	}
+	m.order = m.matchingOrder()
+	return m
+}
+
+// matchingOrder orders pattern vertices so that each one has as many
+// neighbours as possible among those before it, starting from the vertex of
+// highest degree. Ties go to higher degree, then lower index.
+func (m *matcher) matchingOrder() []int {
+	n := len(m.pattern.ids)
+	degree := make([]int, n)
+	for u := range degree {
+		degree[u] = len(m.pattern.out[u]) + len(m.pattern.in[u])
+	}
+
+	placed := make([]bool, n)
+	links := make([]int, n)
+	order := make([]int, 0, n)
+	for len(order) < n {
+		best := -1
+		for u := 0; u < n; u++ {
+			if placed[u] {
+				continue
+			}
+			if best < 0 || links[u] > links[best] || (links[u] == links[best] && degree[u] > degree[best]) {
+				best = u
+			}
+		}
+		placed[best] = true
+		order = append(order, best)
+		for _, v := range m.pattern.out[best] {
+			links[v]++
+		}
+		for _, v := range m.pattern.in[best] {
+			links[v]++
+		}
+	}
+	return order
+}
+
+func (m *matcher) match(depth int, yield func(map[string]string) bool) bool {
+	if depth == len(m.order) {
+		result := make(map[string]string, len(m.core))
+		for p, t := range m.core {
+			result[m.pattern.ids[p]] = m.target.ids[t]
+		}
+		return yield(result)
+	}
+
+	p := m.order[depth]
+	for _, t := range m.candidates(p) {
+		if m.inverse[t] >= 0 || !m.feasible(p, t) {
+			continue
+		}
+		m.core[p], m.inverse[t] = t, p
+		keepGoing := m.match(depth+1, yield)
+		m.core[p], m.inverse[t] = -1, -1
+		if !keepGoing {
+			return false
+		}
+	}
+	return true
+}
+
+// candidates returns the target vertices p may map to. If p is adjacent to a
+// matched pattern vertex q, only the matching neighbours of q's image qualify.
+func (m *matcher) candidates(p int) []int {
+	for _, q := range m.pattern.out[p] {
+		if m.core[q] >= 0 {
+			return m.target.in[m.core[q]]
+		}
+	}
+	for _, q := range m.pattern.in[p] {
+		if m.core[q] >= 0 {
+			return m.target.out[m.core[q]]
+		}
+	}
+	return m.all
+}
+
+// feasible reports whether pattern vertex p can be matched with target
+// vertex t given the current partial mapping.
+func (m *matcher) feasible(p, t int) bool {
+	pat, tgt := m.pattern, m.target
+	if len(pat.out[p]) > len(tgt.out[t]) || len(pat.in[p]) > len(tgt.in[t]) {
+		return false
+	}
+	if m.opts.VertexMatch != nil && !m.opts.VertexMatch(pat.vertices[p], tgt.vertices[t]) {
+		return false
+	}
+
+	// Pattern edges to matched vertices must have matching target edges.
+	unmatchedOut, unmatchedIn := 0, 0
+	for _, q := range pat.out[p] {
+		if m.core[q] < 0 {
+			unmatchedOut++
+			continue
+		}
+		if !m.edgeMatches(pat.edges[p][q], tgt.edges[t][m.core[q]]) {
+			return false
+		}
+	}
+	for _, q := range pat.in[p] {
+		if m.core[q] < 0 {
+			unmatchedIn++
+			continue
+		}
+		if !m.edgeMatches(pat.edges[q][p], tgt.edges[m.core[q]][t]) {
+			return false
+		}
+	}
+
+	// Induced matching forbids target edges between matched vertices that
+	// the pattern does not have.
+	freeOut, freeIn := 0, 0
+	for _, u := range tgt.out[t] {
+		if m.inverse[u] < 0 {
+			freeOut++
+		} else if m.opts.Induced && pat.edges[p][m.inverse[u]] == nil {
+			return false
+		}
+	}
+	for _, u := range tgt.in[t] {
+		if m.inverse[u] < 0 {
+			freeIn++
+		} else if m.opts.Induced && pat.edges[m.inverse[u]][p] == nil {
+			return false
+		}
+	}
+
+	// Unmatched pattern neighbours need distinct unmatched target neighbours.
+	return unmatchedOut <= freeOut && unmatchedIn <= freeIn
+}
+
+func (m *matcher) edgeMatches(pattern, target *Edge) bool {
+	if target == nil {
+		return false
+	}
+	return m.opts.EdgeMatch == nil || m.opts.EdgeMatch(pattern, target)
+}
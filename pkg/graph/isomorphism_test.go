@@ -0,0 +1,150 @@
+package graph
+
+import (
+	"strconv"
+	"testing"
+)
+
+// petersenGraph builds the Petersen graph with vertex IDs prefixed by prefix,
+// numbering the vertices in the given order.
+func petersenGraph(prefix string, order []int) *Graph {
+	g := NewGraph(false)
+	name := func(i int) string { return prefix + strconv.Itoa(order[i]) }
+	for i := 0; i < 5; i++ {
+		g.AddEdge(name(i), name((i+1)%5), 1)     // outer cycle
+		g.AddEdge(name(i), name(5+i), 1)         // spokes
+		g.AddEdge(name(5+i), name(5+(i+2)%5), 1) // inner pentagram
+	}
+	return g
+}
+
+func TestIsomorphic(t *testing.T) {
+	a := petersenGraph("a", []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+	b := petersenGraph("b", []int{7, 3, 9, 0, 5, 2, 8, 1, 6, 4})
+
+	mapping, ok := Isomorphic(a, b)
+	if !ok {
+		t.Fatal("expected relabelled Petersen graphs to be isomorphic")
+	}
+	for _, edge := range a.GetEdges() {
+		if !b.HasEdge(mapping[edge.From().ID()], mapping[edge.To().ID()]) {
+			t.Fatalf("mapping does not preserve edge %s-%s", edge.From().ID(), edge.To().ID())
+		}
+	}
+
+	// The Petersen graph and the 5-prism are both 3-regular on 10 vertices.
+	prism, _ := CycleGraph(5, GeneratorOptions{})
+	for i := 0; i < 5; i++ {
+		prism.AddEdge(strconv.Itoa(i), "x"+strconv.Itoa(i), 1)
+		prism.AddEdge("x"+strconv.Itoa(i), "x"+strconv.Itoa((i+1)%5), 1)
+	}
+	if _, ok := Isomorphic(a, prism); ok {
+		t.Fatal("expected Petersen graph and prism not to be isomorphic")
+	}
+
+	directed, _ := CycleGraph(4, GeneratorOptions{Directed: true})
+	reversed := directed.Transpose()
+	if _, ok := Isomorphic(directed, reversed); !ok {
+		t.Fatal("expected a directed cycle to be isomorphic to its reverse")
+	}
+	path := NewGraph(true)
+	path.AddEdge("0", "1", 1)
+	path.AddEdge("1", "2", 1)
+	path.AddEdge("2", "3", 1)
+	path.AddEdge("0", "3", 1)
+	if _, ok := Isomorphic(directed, path); ok {
+		t.Fatal("expected edge direction to matter")
+	}
+	if _, ok := Isomorphic(directed, NewGraph(false)); ok {
+		t.Fatal("expected mixed directedness to fail")
+	}
+}
+
+func TestIsomorphicWithWeights(t *testing.T) {
+	a := NewGraph(false)
+	a.AddEdge("A", "B", 1)
+	a.AddEdge("B", "C", 2)
+	b := NewGraph(false)
+	b.AddEdge("x", "y", 2)
+	b.AddEdge("y", "z", 2)
+
+	sameWeight := MatchOptions{EdgeMatch: func(p, t *Edge) bool { return p.Weight() == t.Weight() }}
+	if _, ok := IsomorphicWith(a, b, sameWeight); ok {
+		t.Fatal("expected weights to distinguish the paths")
+	}
+	if _, ok := Isomorphic(a, b); !ok {
+		t.Fatal("expected paths to be isomorphic ignoring weights")
+	}
+}
+
+func TestEmbeddingsFanOutFanIn(t *testing.T) {
+	// Fan-out/fan-in motif: one source splits into two branches that rejoin.
+	motif := NewGraph(true)
+	motif.AddEdge("src", "left", 1)
+	motif.AddEdge("src", "right", 1)
+	motif.AddEdge("left", "dst", 1)
+	motif.AddEdge("right", "dst", 1)
+
+	g := NewGraph(true)
+	g.AddEdge("A", "B", 1)
+	g.AddEdge("A", "C", 1)
+	g.AddEdge("A", "D", 1)
+	g.AddEdge("B", "E", 1)
+	g.AddEdge("C", "E", 1)
+	g.AddEdge("D", "E", 1)
+	g.AddEdge("E", "F", 1)
+
+	count := 0
+	for mapping := range g.Embeddings(motif, MatchOptions{}) {
+		count++
+		if mapping["src"] != "A" || mapping["dst"] != "E" {
+			t.Fatalf("unexpected embedding %v", mapping)
+		}
+	}
+	// Ordered pairs of distinct branches among B, C and D.
+	if count != 6 {
+		t.Fatalf("expected 6 embeddings, got %d", count)
+	}
+
+	onlyB := MatchOptions{VertexMatch: func(p, t *Vertex) bool { return p.ID() != "left" || t.ID() == "B" }}
+	count = 0
+	for range g.Embeddings(motif, onlyB) {
+		count++
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 embeddings with left fixed to B, got %d", count)
+	}
+
+	for range g.Embeddings(motif, MatchOptions{}) {
+		break // stopping early must not panic
+	}
+}
+
+func TestEmbeddingsInduced(t *testing.T) {
+	triangle := buildCompleteGraph("A", "B", "C")
+	path := NewGraph(false)
+	path.AddEdge("x", "y", 1)
+	path.AddEdge("y", "z", 1)
+
+	count := 0
+	for range triangle.Embeddings(path, MatchOptions{}) {
+		count++
+	}
+	if count != 6 {
+		t.Fatalf("expected 6 path embeddings in a triangle, got %d", count)
+	}
+	for range triangle.Embeddings(path, MatchOptions{Induced: true}) {
+		t.Fatal("expected no induced path in a triangle")
+	}
+
+	grid, _ := GridGraph(3, 3, GeneratorOptions{})
+	square, _ := CycleGraph(4, GeneratorOptions{})
+	count = 0
+	for range grid.Embeddings(square, MatchOptions{Induced: true}) {
+		count++
+	}
+	// Four unit squares, each with 8 automorphisms of the 4-cycle.
+	if count != 32 {
+		t.Fatalf("expected 32 square embeddings in a 3x3 grid, got %d", count)
+	}
+}
@@ -0,0 +1,330 @@
+package graph
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// LPAStar maintains the shortest path between two fixed vertices of a graph
+// with Lifelong Planning A*. It subscribes to the graph's edge events, and
+// after edges are added, removed or reweighted it repairs only the part of the
+// search the change affects instead of recomputing from scratch.
+//
+// Repairs are lazy: events just mark the affected vertices, and the work
+// happens on the next call to Path. An LPAStar is not safe for concurrent use,
+// and neither is the graph it watches.
+type LPAStar struct {
+	graph     *Graph
+	start     string
+	goal      string
+	heuristic func(current, goal *Vertex) int
+
+	// in holds the weight of every edge keyed by destination and then source,
+	// so predecessors can be found without scanning the graph.
+	in       map[string]map[string]int
+	negative int
+
+	g     map[string]int
+	rhs   map[string]int
+	queue lpaQueue
+	items map[string]*lpaItem
+
+	expansions  int
+	unsubscribe []func()
+}
+
+// NewLPAStar starts maintaining the shortest path from start to goal.
+// heuristic works as in AStar and must be consistent for repairs to stay
+// optimal; nil means no heuristic, which turns the search into an incremental
+// Dijkstra. It returns (nil, false) if start or goal is not in the graph.
+// Call Close when the path is no longer needed so the graph stops notifying it.
+func NewLPAStar(graph *Graph, start, goal string, heuristic func(current, goal *Vertex) int) (*LPAStar, bool) {
+	if graph == nil || !graph.HasVertex(start) || !graph.HasVertex(goal) {
+		return nil, false
+	}
+
+	l := &LPAStar{
+		graph:     graph,
+		start:     start,
+		goal:      goal,
+		heuristic: heuristic,
+		in:        make(map[string]map[string]int),
+		g:         make(map[string]int),
+		rhs:       make(map[string]int),
+		items:     make(map[string]*lpaItem),
+	}
+
+	for fromID, vertex := range graph.vertices {
+		for toID, edge := range vertex.edges {
+			l.setIncoming(fromID, toID, edge.weight)
+		}
+	}
+
+	l.rhs[start] = 0
+	l.enqueue(start)
+
+	l.unsubscribe = []func(){
+		graph.OnEdgeAdded(l.edgeChanged),
+		graph.OnEdgeWeightChanged(l.edgeChanged),
+		graph.OnEdgeRemoved(l.edgeRemoved),
+	}
+	return l, true
+}
+
+// Close stops the LPAStar from following changes to its graph. Edge changes
+// made after Close are not reflected by Path.
+func (l *LPAStar) Close() {
+	for _, unsubscribe := range l.unsubscribe {
+		unsubscribe()
+	}
+	l.unsubscribe = nil
+}
+
+// Path returns the current shortest path and its cost, repairing the search
+// first if the graph changed. It has the same failure contract as
+// ShortestPath: ([]string{}, 0, false) when no path exists, when start or goal
+// has been removed, or while the graph has negative-weight edges.
+func (l *LPAStar) Path() ([]string, int, bool) {
+	if l.negative > 0 || !l.graph.HasVertex(l.start) || !l.graph.HasVertex(l.goal) {
+		return []string{}, 0, false
+	}
+
+	l.computeShortestPath()
+
+	cost, reached := l.g[l.goal]
+	if !reached {
+		return []string{}, 0, false
+	}
+	return l.extractPath(), cost, true
+}
+
+func (l *LPAStar) edgeChanged(event EdgeEvent) {
+	l.setIncoming(event.From, event.To, event.Weight)
+	l.updateVertex(event.To)
+	if !l.graph.directed {
+		l.setIncoming(event.To, event.From, event.Weight)
+		l.updateVertex(event.From)
+	}
+}
+
+func (l *LPAStar) edgeRemoved(event EdgeEvent) {
+	l.removeIncoming(event.From, event.To)
+	l.updateVertex(event.To)
+	if !l.graph.directed {
+		l.removeIncoming(event.To, event.From)
+		l.updateVertex(event.From)
+	}
+}
+
+func (l *LPAStar) setIncoming(from, to string, weight int) {
+	preds, ok := l.in[to]
+	if !ok {
+		preds = make(map[string]int)
+		l.in[to] = preds
+	}
+	if old, ok := preds[from]; ok && old < 0 {
+		l.negative--
+	}
+	if weight < 0 {
+		l.negative++
+	}
+	preds[from] = weight
+}
+
+func (l *LPAStar) removeIncoming(from, to string) {
+	if weight, ok := l.in[to][from]; ok {
+		if weight < 0 {
+			l.negative--
+		}
+		delete(l.in[to], from)
+	}
+}
+
+// value returns m[id], treating vertices the search has not reached as
+// infinitely far away. It only orders the queue; comparisons between g and
+// rhs go through consistent and overconsistent, which tell a cost of
+// math.MaxInt apart from an unreached vertex.
+func (l *LPAStar) value(m map[string]int, id string) int {
+	if v, ok := m[id]; ok {
+		return v
+	}
+	return math.MaxInt
+}
+
+// consistent reports whether g(id) equals rhs(id), both possibly infinite.
+func (l *LPAStar) consistent(id string) bool {
+	g, gOK := l.g[id]
+	rhs, rhsOK := l.rhs[id]
+	return gOK == rhsOK && g == rhs
+}
+
+// overconsistent reports whether g(id) is greater than rhs(id).
+func (l *LPAStar) overconsistent(id string) bool {
+	g, gOK := l.g[id]
+	rhs, rhsOK := l.rhs[id]
+	return rhsOK && (!gOK || g > rhs)
+}
+
+func (l *LPAStar) key(id string) lpaKey {
+	best := min(l.value(l.g, id), l.value(l.rhs, id))
+	h := 0
+	if l.heuristic != nil {
+		current, ok := l.graph.vertices[id]
+		goal, goalOK := l.graph.vertices[l.goal]
+		if ok && goalOK {
+			h = l.heuristic(current, goal)
+		}
+	}
+	return lpaKey{saturatingAdd(best, h), best}
+}
+
+// updateVertex recomputes rhs(id), the best cost offered by its predecessors,
+// and queues the vertex if that disagrees with its settled cost g(id).
+func (l *LPAStar) updateVertex(id string) {
+	if id != l.start {
+		best, found := 0, false
+		for predID, weight := range l.in[id] {
+			g, reached := l.g[predID]
+			if !reached {
+				continue
+			}
+			if cost, ok := addCost(g, weight); ok && (!found || cost < best) {
+				best, found = cost, true
+			}
+		}
+		if found {
+			l.rhs[id] = best
+		} else {
+			delete(l.rhs, id)
+		}
+	}
+
+	if item, ok := l.items[id]; ok {
+		heap.Remove(&l.queue, item.index)
+		delete(l.items, id)
+	}
+	if !l.consistent(id) {
+		l.enqueue(id)
+	}
+}
+
+func (l *LPAStar) enqueue(id string) {
+	item := &lpaItem{vertexID: id, key: l.key(id)}
+	heap.Push(&l.queue, item)
+	l.items[id] = item
+}
+
+func (l *LPAStar) computeShortestPath() {
+	for l.queue.Len() > 0 {
+		top := l.queue[0]
+		if !top.key.less(l.key(l.goal)) && l.consistent(l.goal) {
+			return
+		}
+
+		heap.Pop(&l.queue)
+		delete(l.items, top.vertexID)
+		l.expansions++
+
+		id := top.vertexID
+		if l.overconsistent(id) {
+			l.g[id] = l.rhs[id]
+		} else {
+			delete(l.g, id)
+			l.updateVertex(id)
+		}
+
+		if vertex, ok := l.graph.vertices[id]; ok {
+			for neighborID := range vertex.edges {
+				l.updateVertex(neighborID)
+			}
+		}
+	}
+}
+
+// extractPath walks back from goal along edges that are tight, meaning
+// g(pred) + weight == g(vertex). It searches breadth-first so that
+// zero-weight cycles cannot trap it, and visits predecessors in ID order so
+// ties between equally short paths are broken deterministically.
+func (l *LPAStar) extractPath() []string {
+	next := map[string]string{l.goal: ""}
+	queue := []string{l.goal}
+	for len(queue) > 0 && l.start != queue[0] {
+		current := queue[0]
+		queue = queue[1:]
+		cost := l.g[current]
+
+		preds := make([]string, 0, len(l.in[current]))
+		for predID := range l.in[current] {
+			preds = append(preds, predID)
+		}
+		sort.Strings(preds)
+
+		for _, predID := range preds {
+			if _, seen := next[predID]; seen {
+				continue
+			}
+			g, reached := l.g[predID]
+			if !reached {
+				continue
+			}
+			if total, ok := addCost(g, l.in[current][predID]); ok && total == cost {
+				next[predID] = current
+				queue = append(queue, predID)
+			}
+		}
+	}
+
+	path := []string{l.start}
+	for id := next[l.start]; id != ""; id = next[id] {
+		path = append(path, id)
+	}
+	return path
+}
+
+// lpaKey orders the LPA* queue: by estimated total cost, then by cost so far.
+type lpaKey [2]int
+
+func (k lpaKey) less(other lpaKey) bool {
+	if k[0] != other[0] {
+		return k[0] < other[0]
+	}
+	return k[1] < other[1]
+}
+
+type lpaItem struct {
+	vertexID string
+	key      lpaKey
+	index    int
+}
+
+type lpaQueue []*lpaItem
+
+func (pq lpaQueue) Len() int { return len(pq) }
+
+func (pq lpaQueue) Less(i, j int) bool {
+	if pq[i].key != pq[j].key {
+		return pq[i].key.less(pq[j].key)
+	}
+	return pq[i].vertexID < pq[j].vertexID
+}
+
+func (pq lpaQueue) Swap(i, j int) {
+	pq[i], pq[j] = pq[j], pq[i]
+	pq[i].index = i
+	pq[j].index = j
+}
+
+func (pq *lpaQueue) Push(x any) {
+	item := x.(*lpaItem)
+	item.index = len(*pq)
+	*pq = append(*pq, item)
+}
+
+func (pq *lpaQueue) Pop() any {
+	old := *pq
+	n := len(old)
+	item := old[n-1]
+	*pq = old[:n-1]
+	return item
+}
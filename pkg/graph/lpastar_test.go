@@ -0,0 +1,141 @@
+package graph
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func TestLPAStarRomania(t *testing.T) {
+	g := BuildRomaniaGraph()
+	l, ok := NewLPAStar(g, "Arad", "Bucharest", nil)
+	if !ok {
+		t.Fatal("expected NewLPAStar to succeed")
+	}
+	defer l.Close()
+
+	path, cost, ok := l.Path()
+	if !ok || cost != 418 || path[len(path)-1] != "Bucharest" {
+		t.Fatalf("expected cost 418, got %v %d", path, cost)
+	}
+
+	g.AddEdge("Pitesti", "Bucharest", 500)
+	if _, cost, _ := l.Path(); cost != 450 {
+		t.Fatalf("expected detour through Fagaras costing 450, got %d", cost)
+	}
+
+	g.RemoveVertex("Fagaras")
+	if _, cost, _ := l.Path(); cost != 817 {
+		t.Fatalf("expected the reweighted Pitesti road costing 817, got %d", cost)
+	}
+
+	g.AddEdge("Arad", "Bucharest", 100)
+	path, cost, _ = l.Path()
+	if cost != 100 || len(path) != 2 {
+		t.Fatalf("expected direct road, got %v %d", path, cost)
+	}
+
+	g.AddEdge("Arad", "Zerind", -1)
+	if _, _, ok := l.Path(); ok {
+		t.Fatal("expected failure with a negative edge")
+	}
+	g.AddEdge("Arad", "Zerind", 75)
+	if _, _, ok := l.Path(); !ok {
+		t.Fatal("expected recovery once the negative edge is gone")
+	}
+
+	g.RemoveVertex("Bucharest")
+	if _, _, ok := l.Path(); ok {
+		t.Fatal("expected failure after the goal is removed")
+	}
+}
+
+func TestLPAStarCostingExactlyMaxInt(t *testing.T) {
+	g := NewGraph(true)
+	g.AddEdge("A", "B", math.MaxInt)
+	g.AddEdge("C", "B", 0)
+	l, ok := NewLPAStar(g, "A", "B", nil)
+	if !ok {
+		t.Fatal("expected NewLPAStar to succeed")
+	}
+	defer l.Close()
+
+	path, cost, ok := l.Path()
+	if !ok || cost != math.MaxInt || len(path) != 2 || path[0] != "A" {
+		t.Fatalf("expected A-B costing exactly MaxInt, got %v %d", path, cost)
+	}
+
+	g.RemoveEdge("A", "B")
+	if _, _, ok := l.Path(); ok {
+		t.Fatal("expected no path once A-B is removed")
+	}
+	g.AddEdge("A", "B", 5)
+	if _, cost, ok := l.Path(); !ok || cost != 5 {
+		t.Fatalf("expected A-B costing 5, got %d", cost)
+	}
+}
+
+func TestLPAStarMatchesDijkstraUnderUpdates(t *testing.T) {
+	for _, directed := range []bool{false, true} {
+		g, _ := GridGraph(12, 12, GeneratorOptions{Directed: directed, Seed: 3, Weight: UniformWeight(1, 9)})
+		l, _ := NewLPAStar(g, "0,0", "11,11", ManhattanHeuristic(GridCoordinates))
+		r := rand.New(rand.NewSource(5))
+
+		for step := 0; step < 200; step++ {
+			from := strconv.Itoa(r.Intn(12)) + "," + strconv.Itoa(r.Intn(12))
+			to := strconv.Itoa(r.Intn(12)) + "," + strconv.Itoa(r.Intn(12))
+			if r.Intn(3) == 0 {
+				g.RemoveEdge(from, to)
+			} else if edge, ok := g.GetEdge(from, to); ok {
+				g.AddEdge(from, to, edge.Weight()+r.Intn(9)-4)
+			} else if from != to {
+				// Keep the Manhattan heuristic admissible for added edges.
+				g.AddEdge(from, to, ManhattanHeuristic(GridCoordinates)(g.vertices[from], g.vertices[to])+r.Intn(5))
+			}
+
+			_, want, wantOK := g.ShortestPath("0,0", "11,11")
+			path, got, gotOK := l.Path()
+			if wantOK != gotOK || want != got {
+				t.Fatalf("step %d: expected (%d, %v), got (%d, %v)", step, want, wantOK, got, gotOK)
+			}
+			if gotOK && pathCost(t, g, path) != got {
+				t.Fatalf("step %d: path %v does not cost %d", step, path, got)
+			}
+		}
+		l.Close()
+	}
+}
+
+func TestLPAStarRepairsIncrementally(t *testing.T) {
+	g, _ := GridGraph(40, 40, GeneratorOptions{Seed: 1, Weight: UniformWeight(1, 9)})
+	l, _ := NewLPAStar(g, "0,0", "39,39", nil)
+
+	l.Path()
+	initial := l.expansions
+
+	// A far corner edge does not lie on any shortest path to the goal.
+	g.AddEdge("39,0", "38,0", 9)
+	l.Path()
+	if repair := l.expansions - initial; repair*10 > initial {
+		t.Fatalf("expected a small repair, expanded %d vertices after %d initially", repair, initial)
+	}
+
+	l.Close()
+	if len(g.events.added.listeners) != 0 || len(g.events.removed.listeners) != 0 {
+		t.Fatal("expected Close to unsubscribe from the graph")
+	}
+}
+
+func pathCost(t *testing.T, g *Graph, path []string) int {
+	t.Helper()
+	cost := 0
+	for i := 0; i+1 < len(path); i++ {
+		edge, ok := g.GetEdge(path[i], path[i+1])
+		if !ok {
+			t.Fatalf("path uses missing edge %s -> %s", path[i], path[i+1])
+		}
+		cost += edge.Weight()
+	}
+	return cost
+}
@@ -0,0 +1,147 @@
+package graph
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"math"
+)
+
+// SearchOptions bounds the work done by ShortestPathContext and AStarContext.
+// The zero value imposes no bound.
+type SearchOptions struct {
+	// MaxExpansions limits how many vertices the search may expand, that is
+	// take from the frontier and relax the edges of; 0 means no limit.
+	MaxExpansions int
+}
+
+// SearchError reports a search that stopped before reaching its goal because
+// its context was done or its expansion budget ran out. Err is ctx.Err() or
+// ErrBudgetExceeded, so errors.Is(err, context.DeadlineExceeded) and
+// errors.Is(err, ErrBudgetExceeded) work on the returned error.
+type SearchError struct {
+	Err error
+	// Expanded is the number of vertices expanded before the search stopped.
+	Expanded int
+	// Path and Cost describe the best partial result: the shortest path to
+	// the settled vertex that looked closest to the goal.
+	Path []string
+	Cost int
+}
+
+func (e *SearchError) Error() string {
+	return fmt.Sprintf("graph: search stopped after %d expansions: %v", e.Expanded, e.Err)
+}
+
+func (e *SearchError) Unwrap() error { return e.Err }
+
+// ShortestPathContext is ShortestPathChecked that gives up when ctx is done
+// or after opts.MaxExpansions expansions. It then returns the best partial
+// path found so far, its cost and a *SearchError; without a heuristic the
+// best partial path leads to the farthest vertex settled. A search that
+// ends on its own reports the same errors as ShortestPathChecked.
+func (g *Graph) ShortestPathContext(ctx context.Context, start, goal string, opts SearchOptions) ([]string, int, error) {
+	return g.bestFirstSearch(ctx, start, goal, nil, opts)
+}
+
+// AStarContext is AStarChecked that gives up when ctx is done or after
+// opts.MaxExpansions expansions. It then returns a *SearchError together with
+// the path to the settled vertex with the lowest heuristic estimate, ties
+// going to the farthest one, and that path's cost.
+func (g *Graph) AStarContext(ctx context.Context, start, goal string, heuristic func(current, goal *Vertex) int, opts SearchOptions) ([]string, int, error) {
+	if heuristic == nil {
+		return []string{}, 0, ErrNilHeuristic
+	}
+	return g.bestFirstSearch(ctx, start, goal, heuristic, opts)
+}
+
+// bestFirstSearch runs A* with the given heuristic, or Dijkstra when it is
+// nil. ctx is checked before every expansion.
+func (g *Graph) bestFirstSearch(ctx context.Context, start, goal string, heuristic func(current, goal *Vertex) int, opts SearchOptions) ([]string, int, error) {
+	startVertex, startOK := g.GetVertex(start)
+	goalVertex, goalOK := g.GetVertex(goal)
+	if !startOK || !goalOK {
+		return []string{}, 0, ErrVertexNotFound
+	}
+
+	if g.hasNegativeWeightEdge() {
+		return []string{}, 0, ErrNegativeWeight
+	}
+
+	if start == goal {
+		return []string{start}, 0, nil
+	}
+
+	estimate := func(v *Vertex) int {
+		if heuristic == nil {
+			return 0
+		}
+		return heuristic(v, goalVertex)
+	}
+
+	// gScore holds reached vertices only, so that a cost of exactly
+	// math.MaxInt is not mistaken for "unreached".
+	gScore := map[string]int{start: 0}
+	prev := make(map[string]string, len(g.vertices))
+
+	pq := &priorityQueue{}
+	heap.Init(pq)
+	heap.Push(pq, &pqItem{vertexID: start, priority: estimate(startVertex)})
+
+	expanded := 0
+	best, bestEstimate := start, math.MaxInt
+	done := ctx.Done()
+	for pq.Len() > 0 {
+		current := heap.Pop(pq).(*pqItem)
+		currentVertex := g.vertices[current.vertexID]
+
+		h := estimate(currentVertex)
+		if current.priority > saturatingAdd(gScore[current.vertexID], h) {
+			continue
+		}
+
+		if current.vertexID == goal {
+			break
+		}
+
+		if h < bestEstimate || (h == bestEstimate && gScore[current.vertexID] >= gScore[best]) {
+			best, bestEstimate = current.vertexID, h
+		}
+
+		var stop error
+		if opts.MaxExpansions > 0 && expanded >= opts.MaxExpansions {
+			stop = ErrBudgetExceeded
+		} else if done != nil {
+			select {
+			case <-done:
+				stop = ctx.Err()
+			default:
+			}
+		}
+		if stop != nil {
+			path := buildPath(prev, start, best)
+			return path, gScore[best], &SearchError{Err: stop, Expanded: expanded, Path: path, Cost: gScore[best]}
+		}
+		expanded++
+
+		for neighborID, edge := range currentVertex.edges {
+			tentative, ok := addCost(gScore[current.vertexID], edge.weight)
+			if !ok {
+				continue
+			}
+			if known, reached := gScore[neighborID]; !reached || tentative < known {
+				gScore[neighborID] = tentative
+				prev[neighborID] = current.vertexID
+				priority := saturatingAdd(tentative, estimate(g.vertices[neighborID]))
+				heap.Push(pq, &pqItem{vertexID: neighborID, priority: priority})
+			}
+		}
+	}
+
+	cost, reached := gScore[goal]
+	if !reached {
+		return []string{}, 0, g.unreachedError(start, goal)
+	}
+
+	return buildPath(prev, start, goal), cost, nil
+}
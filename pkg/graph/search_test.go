@@ -0,0 +1,88 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestShortestPathContextCompletes(t *testing.T) {
+	g := BuildRomaniaGraph()
+	path, cost, err := g.ShortestPathContext(context.Background(), "Arad", "Bucharest", SearchOptions{MaxExpansions: 100})
+	if err != nil || cost != 418 || path[len(path)-1] != "Bucharest" {
+		t.Fatalf("expected cost 418, got %v %d %v", path, cost, err)
+	}
+
+	if _, _, err := g.ShortestPathContext(context.Background(), "Arad", "Nowhere", SearchOptions{}); !errors.Is(err, ErrVertexNotFound) {
+		t.Fatalf("expected ErrVertexNotFound, got %v", err)
+	}
+}
+
+func TestShortestPathContextCancelled(t *testing.T) {
+	g := BuildRomaniaGraph()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	path, cost, err := g.ShortestPathContext(ctx, "Arad", "Bucharest", SearchOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	var searchErr *SearchError
+	if !errors.As(err, &searchErr) || searchErr.Expanded != 0 {
+		t.Fatalf("expected a *SearchError with no expansions, got %#v", err)
+	}
+	if !reflect.DeepEqual(path, []string{"Arad"}) || cost != 0 {
+		t.Fatalf("expected partial path [Arad] at cost 0, got %v %d", path, cost)
+	}
+
+	ctx, cancel = context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	if _, _, err := g.AStarContext(ctx, "Arad", "Bucharest", RomaniaBucharestHeuristic, SearchOptions{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestShortestPathContextBudget(t *testing.T) {
+	g := BuildRomaniaGraph()
+	path, cost, err := g.ShortestPathContext(context.Background(), "Arad", "Bucharest", SearchOptions{MaxExpansions: 5})
+	if !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("expected ErrBudgetExceeded, got %v", err)
+	}
+	var searchErr *SearchError
+	if !errors.As(err, &searchErr) || searchErr.Expanded != 5 {
+		t.Fatalf("expected 5 expansions, got %#v", err)
+	}
+	if !reflect.DeepEqual(searchErr.Path, path) || searchErr.Cost != cost {
+		t.Fatalf("expected the error to carry the partial result %v %d, got %v %d", path, cost, searchErr.Path, searchErr.Cost)
+	}
+	// Dijkstra settles Arad, Zerind, Timisoara, Sibiu and Oradea, then stops
+	// on Rimnicu Vilcea, the farthest settled vertex.
+	expected := []string{"Arad", "Sibiu", "Rimnicu Vilcea"}
+	if !reflect.DeepEqual(path, expected) || cost != 220 || pathCost(t, g, path) != cost {
+		t.Fatalf("expected %v at cost 220, got %v %d", expected, path, cost)
+	}
+}
+
+func TestAStarContextBudget(t *testing.T) {
+	g := BuildRomaniaGraph()
+	// A* expands Arad and Sibiu, then stops on Rimnicu Vilcea, which is the
+	// settled vertex closest to Bucharest.
+	path, cost, err := g.AStarContext(context.Background(), "Arad", "Bucharest", RomaniaBucharestHeuristic, SearchOptions{MaxExpansions: 2})
+	if !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("expected ErrBudgetExceeded, got %v", err)
+	}
+	expected := []string{"Arad", "Sibiu", "Rimnicu Vilcea"}
+	if !reflect.DeepEqual(path, expected) || cost != 220 {
+		t.Fatalf("expected %v at cost 220, got %v %d", expected, path, cost)
+	}
+
+	if _, _, err := g.AStarContext(context.Background(), "Arad", "Bucharest", nil, SearchOptions{}); !errors.Is(err, ErrNilHeuristic) {
+		t.Fatalf("expected ErrNilHeuristic, got %v", err)
+	}
+	path, cost, err = g.AStarContext(context.Background(), "Arad", "Bucharest", RomaniaBucharestHeuristic, SearchOptions{MaxExpansions: 5})
+	if err != nil || cost != 418 || len(path) != 5 {
+		t.Fatalf("expected A* to finish within 5 expansions at cost 418, got %v %d %v", path, cost, err)
+	}
+}
@@ -1,135 +1,70 @@
 package graph
 
-import "container/heap"
+import "context"
 
 // ShortestPath returns the shortest path between start and goal using Dijkstra.
 // It returns ([]string{}, 0, false) when no path exists or when the graph
-// contains negative-weight edges.
+// contains negative-weight edges. Path costs are checked for overflow, so a
+// goal reachable only through paths costing more than math.MaxInt is reported
+// as not found; ShortestPathChecked tells the two cases apart.
 func (g *Graph) ShortestPath(start, goal string) ([]string, int, bool) {
-	if start == "" || goal == "" {
-		return []string{}, 0, false
-	}
-
-	if g.hasNegativeWeightEdge() {
-		return []string{}, 0, false
-	}
-
-	if !g.HasVertex(start) || !g.HasVertex(goal) {
-		return []string{}, 0, false
-	}
-
-	if start == goal {
-		return []string{start}, 0, true
-	}
-
-	const inf = int(^uint(0) >> 1)
-	dist := make(map[string]int, len(g.vertices))
-	prev := make(map[string]string, len(g.vertices))
-	for id := range g.vertices {
-		dist[id] = inf
-	}
-	dist[start] = 0
-
-	pq := &priorityQueue{}
-	heap.Init(pq)
-	heap.Push(pq, &pqItem{vertexID: start, priority: 0})
-
-	for pq.Len() > 0 {
-		current := heap.Pop(pq).(*pqItem)
-		if current.priority > dist[current.vertexID] {
-			continue
-		}
-
-		if current.vertexID == goal {
-			break
-		}
-
-		vertex := g.vertices[current.vertexID]
-		for neighborID, edge := range vertex.edges {
-			tentative := dist[current.vertexID] + edge.weight
-			if tentative < dist[neighborID] {
-				dist[neighborID] = tentative
-				prev[neighborID] = current.vertexID
-				heap.Push(pq, &pqItem{vertexID: neighborID, priority: tentative})
-			}
-		}
-	}
-
-	if dist[goal] == inf {
+	path, cost, err := g.ShortestPathChecked(start, goal)
+	if err != nil {
 		return []string{}, 0, false
 	}
+	return path, cost, true
+}
 
-	return buildPath(prev, start, goal), dist[goal], true
+// ShortestPathChecked is ShortestPath reporting why no path was returned:
+// ErrVertexNotFound, ErrNegativeWeight, ErrNoPath, or ErrCostOverflow when
+// the goal is reachable but every path to it costs more than an int can hold.
+// ShortestPathBig computes such costs exactly.
+func (g *Graph) ShortestPathChecked(start, goal string) ([]string, int, error) {
+	return g.bestFirstSearch(context.Background(), start, goal, nil, SearchOptions{})
 }
 
 // AStar returns the shortest path between start and goal using A*.
 // It returns ([]string{}, 0, false) when heuristic is nil, when no path exists,
-// or when the graph contains negative-weight edges.
+// or when the graph contains negative-weight edges. Like ShortestPath, it
+// reports goals reachable only through overflowing paths as not found.
 func (g *Graph) AStar(start, goal string, heuristic func(current, goal *Vertex) int) ([]string, int, bool) {
-	if heuristic == nil {
+	path, cost, err := g.AStarChecked(start, goal, heuristic)
+	if err != nil {
 		return []string{}, 0, false
 	}
+	return path, cost, true
+}
 
-	if start == "" || goal == "" {
-		return []string{}, 0, false
-	}
-
-	if g.hasNegativeWeightEdge() {
-		return []string{}, 0, false
-	}
-
-	startVertex, startOK := g.GetVertex(start)
-	goalVertex, goalOK := g.GetVertex(goal)
-	if !startOK || !goalOK {
-		return []string{}, 0, false
-	}
-
-	if start == goal {
-		return []string{start}, 0, true
-	}
-
-	const inf = int(^uint(0) >> 1)
-	gScore := make(map[string]int, len(g.vertices))
-	prev := make(map[string]string, len(g.vertices))
-	for id := range g.vertices {
-		gScore[id] = inf
+// AStarChecked is AStar reporting why no path was returned: ErrNilHeuristic,
+// ErrVertexNotFound, ErrNegativeWeight, ErrNoPath or ErrCostOverflow.
+// Priorities (cost plus heuristic) saturate at math.MaxInt instead of
+// wrapping around.
+func (g *Graph) AStarChecked(start, goal string, heuristic func(current, goal *Vertex) int) ([]string, int, error) {
+	if heuristic == nil {
+		return []string{}, 0, ErrNilHeuristic
 	}
-	gScore[start] = 0
-
-	pq := &priorityQueue{}
-	heap.Init(pq)
-	heap.Push(pq, &pqItem{vertexID: start, priority: heuristic(startVertex, goalVertex)})
-
-	for pq.Len() > 0 {
-		current := heap.Pop(pq).(*pqItem)
-		currentVertex := g.vertices[current.vertexID]
-
-		expectedPriority := gScore[current.vertexID] + heuristic(currentVertex, goalVertex)
-		if current.priority > expectedPriority {
-			continue
-		}
-
-		if current.vertexID == goal {
-			break
-		}
+	return g.bestFirstSearch(context.Background(), start, goal, heuristic, SearchOptions{})
+}
 
-		for neighborID, edge := range currentVertex.edges {
-			tentativeG := gScore[current.vertexID] + edge.weight
-			if tentativeG < gScore[neighborID] {
-				gScore[neighborID] = tentativeG
-				prev[neighborID] = current.vertexID
-				neighborVertex := g.vertices[neighborID]
-				fScore := tentativeG + heuristic(neighborVertex, goalVertex)
-				heap.Push(pq, &pqItem{vertexID: neighborID, priority: fScore})
+// unreachedError explains why a search with non-negative weights did not
+// reach goal: if goal is reachable at all, every path to it overflowed.
+func (g *Graph) unreachedError(start, goal string) error {
+	visited := map[string]bool{start: true}
+	queue := []string{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for neighborID := range g.vertices[current].edges {
+			if neighborID == goal {
+				return ErrCostOverflow
+			}
+			if !visited[neighborID] {
+				visited[neighborID] = true
+				queue = append(queue, neighborID)
 			}
 		}
 	}
-
-	if gScore[goal] == inf {
-		return []string{}, 0, false
-	}
-
-	return buildPath(prev, start, goal), gScore[goal], true
+	return ErrNoPath
 }
 
 func (g *Graph) hasNegativeWeightEdge() bool {
@@ -0,0 +1,350 @@
+package graph
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// Stats is a summary report of a graph's structure. It can be printed with
+// String or marshalled to JSON.
+//
+// Triangle and clustering figures ignore edge direction. Distances follow
+// edge direction and count hops, or sum weights when StatsOptions.Weighted is
+// set. Diameter, Radius and AveragePathLength only consider pairs of vertices
+// joined by a path; Connected reports whether every ordered pair is, that is
+// whether the graph is (strongly) connected.
+type Stats struct {
+	Vertices int     `json:"vertices"`
+	Edges    int     `json:"edges"`
+	Directed bool    `json:"directed"`
+	Density  float64 `json:"density"`
+
+	MinDegree          int         `json:"min_degree"`
+	MaxDegree          int         `json:"max_degree"`
+	AverageDegree      float64     `json:"average_degree"`
+	DegreeDistribution map[int]int `json:"degree_distribution"`
+
+	Triangles                    int     `json:"triangles"`
+	GlobalClusteringCoefficient  float64 `json:"global_clustering_coefficient"`
+	AverageClusteringCoefficient float64 `json:"average_clustering_coefficient"`
+
+	Connected         bool    `json:"connected"`
+	Weighted          bool    `json:"weighted"`
+	Exact             bool    `json:"exact"`
+	Diameter          int     `json:"diameter"`
+	Radius            int     `json:"radius"`
+	AveragePathLength float64 `json:"average_path_length"`
+}
+
+// StatsOptions configures Graph.Stats.
+type StatsOptions struct {
+	// Weighted measures distances by edge weight instead of hop count.
+	Weighted bool
+	// Approximate estimates the distance figures from Samples sources instead
+	// of searching from every vertex. Diameter is then a lower bound, Radius
+	// an upper bound and AveragePathLength a sample mean.
+	Approximate bool
+	// Samples is the number of sources used when Approximate is set;
+	// values <= 0 use 16. With at least as many samples as vertices the
+	// figures are exact.
+	Samples int
+	// Seed picks the sampled sources.
+	Seed int64
+}
+
+// Stats computes a statistics report for the graph. Exact distance figures
+// need a search from every vertex, O(V * (V + E) log V) with weights, so
+// use opts.Approximate for large graphs. It returns false if opts.Weighted
+// is set and the graph has negative-weight edges.
+func (g *Graph) Stats(opts StatsOptions) (Stats, bool) {
+	if opts.Weighted && g.hasNegativeWeightEdge() {
+		return Stats{}, false
+	}
+
+	ids := g.sortedVertexIDs()
+	stats := Stats{
+		Vertices:           len(ids),
+		Edges:              len(g.GetEdges()),
+		Directed:           g.directed,
+		Density:            g.Density(),
+		DegreeDistribution: g.DegreeDistribution(),
+		Weighted:           opts.Weighted,
+	}
+
+	if len(ids) > 0 {
+		stats.MinDegree = -1
+		total := 0
+		for degree, count := range stats.DegreeDistribution {
+			if stats.MinDegree < 0 || degree < stats.MinDegree {
+				stats.MinDegree = degree
+			}
+			stats.MaxDegree = max(stats.MaxDegree, degree)
+			total += degree * count
+		}
+		stats.AverageDegree = float64(total) / float64(len(ids))
+	}
+
+	adj := g.undirectedNeighbors(ids)
+	local, triangles, triples := clustering(adj)
+	stats.Triangles = triangles
+	if triples > 0 {
+		stats.GlobalClusteringCoefficient = 3 * float64(triangles) / float64(triples)
+	}
+	for _, c := range local {
+		stats.AverageClusteringCoefficient += c
+	}
+	if len(ids) > 0 {
+		stats.AverageClusteringCoefficient /= float64(len(ids))
+	}
+
+	g.distanceStats(ids, opts, &stats)
+	return stats, true
+}
+
+// String formats the report as aligned "name: value" lines.
+func (s Stats) String() string {
+	var b strings.Builder
+	kind := "undirected"
+	if s.Directed {
+		kind = "directed"
+	}
+	fmt.Fprintf(&b, "vertices:               %d (%s)\n", s.Vertices, kind)
+	fmt.Fprintf(&b, "edges:                  %d\n", s.Edges)
+	fmt.Fprintf(&b, "density:                %.4f\n", s.Density)
+	fmt.Fprintf(&b, "degree (min/avg/max):   %d / %.2f / %d\n", s.MinDegree, s.AverageDegree, s.MaxDegree)
+	fmt.Fprintf(&b, "triangles:              %d\n", s.Triangles)
+	fmt.Fprintf(&b, "clustering (global):    %.4f\n", s.GlobalClusteringCoefficient)
+	fmt.Fprintf(&b, "clustering (average):   %.4f\n", s.AverageClusteringCoefficient)
+	fmt.Fprintf(&b, "connected:              %t\n", s.Connected)
+
+	qualifier := ""
+	if !s.Exact {
+		qualifier = " (approximate)"
+	}
+	fmt.Fprintf(&b, "diameter:               %d%s\n", s.Diameter, qualifier)
+	fmt.Fprintf(&b, "radius:                 %d%s\n", s.Radius, qualifier)
+	fmt.Fprintf(&b, "average path length:    %.4f%s\n", s.AveragePathLength, qualifier)
+
+	degrees := make([]int, 0, len(s.DegreeDistribution))
+	for degree := range s.DegreeDistribution {
+		degrees = append(degrees, degree)
+	}
+	sort.Ints(degrees)
+	b.WriteString("degree distribution:\n")
+	for _, degree := range degrees {
+		fmt.Fprintf(&b, "  %4d: %d\n", degree, s.DegreeDistribution[degree])
+	}
+	return b.String()
+}
+
+// Density returns the fraction of possible edges that are present: E / (V(V-1))
+// for directed graphs and 2E / (V(V-1)) for undirected ones. Graphs with fewer
+// than two vertices have density 0.
+func (g *Graph) Density() float64 {
+	n := len(g.vertices)
+	if n < 2 {
+		return 0
+	}
+	edges := float64(len(g.GetEdges()))
+	if !g.directed {
+		edges *= 2
+	}
+	return edges / float64(n*(n-1))
+}
+
+// DegreeDistribution returns how many vertices have each degree, as reported
+// by Degree. In-degrees are counted in one pass over the edges, so it runs
+// in O(V + E).
+func (g *Graph) DegreeDistribution() map[int]int {
+	inDegree := make(map[string]int, len(g.vertices))
+	if g.directed {
+		for _, vertex := range g.vertices {
+			for neighborID := range vertex.edges {
+				inDegree[neighborID]++
+			}
+		}
+	}
+
+	distribution := make(map[int]int)
+	for id, vertex := range g.vertices {
+		distribution[len(vertex.edges)+inDegree[id]]++
+	}
+	return distribution
+}
+
+// TriangleCount returns the number of triangles, ignoring edge direction.
+func (g *Graph) TriangleCount() int {
+	_, triangles, _ := clustering(g.undirectedNeighbors(g.sortedVertexIDs()))
+	return triangles
+}
+
+// LocalClusteringCoefficient returns, for every vertex, the fraction of pairs
+// of its neighbours that are adjacent, ignoring edge direction. Vertices with
+// fewer than two neighbours have coefficient 0.
+func (g *Graph) LocalClusteringCoefficient() map[string]float64 {
+	ids := g.sortedVertexIDs()
+	local, _, _ := clustering(g.undirectedNeighbors(ids))
+	coefficients := make(map[string]float64, len(ids))
+	for i, id := range ids {
+		coefficients[id] = local[i]
+	}
+	return coefficients
+}
+
+// GlobalClusteringCoefficient returns the transitivity of the graph: three
+// times the number of triangles divided by the number of connected triples
+// (paths of length two), ignoring edge direction.
+func (g *Graph) GlobalClusteringCoefficient() float64 {
+	_, triangles, triples := clustering(g.undirectedNeighbors(g.sortedVertexIDs()))
+	if triples == 0 {
+		return 0
+	}
+	return 3 * float64(triangles) / float64(triples)
+}
+
+// clustering counts triangles with the forward algorithm: edges point from
+// lower to higher (degree, index) rank, so each triangle is found exactly
+// once from its lowest-ranked vertex in O(E^1.5). It returns the local
+// clustering coefficient of every vertex, the number of triangles and the
+// number of connected triples.
+func clustering(adj [][]int) ([]float64, int, int) {
+	n := len(adj)
+	rank := func(u, v int) bool {
+		if len(adj[u]) != len(adj[v]) {
+			return len(adj[u]) < len(adj[v])
+		}
+		return u < v
+	}
+
+	forward := make([][]int, n)
+	for u, neighbors := range adj {
+		for _, v := range neighbors {
+			if rank(u, v) {
+				forward[u] = append(forward[u], v)
+			}
+		}
+	}
+
+	perVertex := make([]int, n)
+	marked := make([]bool, n)
+	triangles := 0
+	for u := range forward {
+		for _, v := range forward[u] {
+			marked[v] = true
+		}
+		for _, v := range forward[u] {
+			for _, w := range forward[v] {
+				if marked[w] {
+					triangles++
+					perVertex[u]++
+					perVertex[v]++
+					perVertex[w]++
+				}
+			}
+		}
+		for _, v := range forward[u] {
+			marked[v] = false
+		}
+	}
+
+	local := make([]float64, n)
+	triples := 0
+	for u, neighbors := range adj {
+		d := len(neighbors)
+		pairs := d * (d - 1) / 2
+		triples += pairs
+		if pairs > 0 {
+			local[u] = float64(perVertex[u]) / float64(pairs)
+		}
+	}
+	return local, triangles, triples
+}
+
+// distanceStats fills in the connectivity and distance figures of stats from
+// single-source searches, from every vertex or from a seeded sample.
+func (g *Graph) distanceStats(ids []string, opts StatsOptions, stats *Stats) {
+	n := len(ids)
+	adj := g.indexedAdjacency(ids)
+
+	samples := opts.Samples
+	if samples <= 0 {
+		samples = 16
+	}
+	sources := make([]int, n)
+	for i := range sources {
+		sources[i] = i
+	}
+	stats.Exact = !opts.Approximate || samples >= n
+	if !stats.Exact {
+		r := rand.New(rand.NewSource(opts.Seed))
+		r.Shuffle(n, func(i, j int) { sources[i], sources[j] = sources[j], sources[i] })
+		sources = sources[:samples]
+	}
+
+	stats.Connected = stronglyConnected(adj)
+	radius := -1
+	pathTotal, pathCount := 0.0, 0
+	farthest := make([]int, 0, len(sources))
+	for _, s := range sources {
+		eccentricity, far, reached := 0, s, 0
+		for v, d := range singleSourceDistances(adj, s, opts.Weighted) {
+			if v == s {
+				continue
+			}
+			if d < 0 {
+				continue
+			}
+			reached++
+			pathTotal += float64(d)
+			if d > eccentricity {
+				eccentricity, far = d, v
+			}
+		}
+		pathCount += reached
+		stats.Diameter = max(stats.Diameter, eccentricity)
+		if reached > 0 && (radius < 0 || eccentricity < radius) {
+			radius = eccentricity
+		}
+		farthest = append(farthest, far)
+	}
+
+	// Double sweep: the vertex farthest from a source tends to be an end of a
+	// longest shortest path, so searching from it tightens the diameter bound.
+	if !stats.Exact {
+		for _, s := range farthest {
+			for _, d := range singleSourceDistances(adj, s, opts.Weighted) {
+				stats.Diameter = max(stats.Diameter, d)
+			}
+		}
+	}
+
+	stats.Radius = max(radius, 0)
+	if pathCount > 0 {
+		stats.AveragePathLength = pathTotal / float64(pathCount)
+	}
+}
+
+// stronglyConnected reports whether every vertex of adj reaches every other,
+// by checking that vertex 0 reaches all vertices and is reached by all.
+func stronglyConnected(adj [][]indexedEdge) bool {
+	if len(adj) == 0 {
+		return true
+	}
+
+	reverse := make([][]indexedEdge, len(adj))
+	for u, edges := range adj {
+		for _, edge := range edges {
+			reverse[edge.to] = append(reverse[edge.to], indexedEdge{to: u})
+		}
+	}
+	for _, graph := range [][][]indexedEdge{adj, reverse} {
+		for _, d := range singleSourceDistances(graph, 0, false) {
+			if d < 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,141 @@
+package graph
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestTrianglesAndClustering(t *testing.T) {
+	k4 := buildCompleteGraph("A", "B", "C", "D")
+	if k4.TriangleCount() != 4 || k4.GlobalClusteringCoefficient() != 1 {
+		t.Fatal("expected K4 to have 4 triangles and transitivity 1")
+	}
+
+	// A triangle with a pendant vertex D attached to C.
+	g := buildCompleteGraph("A", "B", "C")
+	g.AddEdge("C", "D", 1)
+	local := g.LocalClusteringCoefficient()
+	if local["A"] != 1 || local["D"] != 0 || math.Abs(local["C"]-1.0/3) > 1e-9 {
+		t.Fatalf("unexpected local clustering %v", local)
+	}
+	// One triangle; triples: A 1, B 1, C 3, D 0.
+	if got := g.GlobalClusteringCoefficient(); math.Abs(got-3.0/5) > 1e-9 {
+		t.Fatalf("expected transitivity 0.6, got %.4f", got)
+	}
+
+	karate := karateClubGraph()
+	if karate.TriangleCount() != 45 {
+		t.Fatalf("expected 45 triangles in the karate club, got %d", karate.TriangleCount())
+	}
+
+	directed := NewGraph(true)
+	directed.AddEdge("A", "B", 1)
+	directed.AddEdge("B", "C", 1)
+	directed.AddEdge("C", "A", 1)
+	directed.AddEdge("A", "C", 1)
+	if directed.TriangleCount() != 1 {
+		t.Fatal("expected direction to be ignored for triangles")
+	}
+}
+
+func TestDensityAndDegrees(t *testing.T) {
+	if d := buildCompleteGraph("A", "B", "C").Density(); d != 1 {
+		t.Fatalf("expected complete graph density 1, got %.2f", d)
+	}
+	star, _ := StarGraph(5, GeneratorOptions{Directed: true})
+	if d := star.Density(); d != 4.0/20 {
+		t.Fatalf("expected directed star density 0.2, got %.2f", d)
+	}
+	if distribution := star.DegreeDistribution(); distribution[1] != 4 || distribution[4] != 1 {
+		t.Fatalf("unexpected degree distribution %v", distribution)
+	}
+	if NewGraph(false).Density() != 0 {
+		t.Fatal("expected empty graph density 0")
+	}
+}
+
+func TestStats(t *testing.T) {
+	cycle, _ := CycleGraph(6, GeneratorOptions{})
+	stats, ok := cycle.Stats(StatsOptions{})
+	if !ok {
+		t.Fatal("expected Stats to succeed")
+	}
+	if stats.Vertices != 6 || stats.Edges != 6 || !stats.Connected || !stats.Exact {
+		t.Fatalf("unexpected report %+v", stats)
+	}
+	if stats.Diameter != 3 || stats.Radius != 3 {
+		t.Fatalf("expected C6 diameter and radius 3, got %d and %d", stats.Diameter, stats.Radius)
+	}
+	// Distances from any vertex of C6: 1, 1, 2, 2, 3.
+	if stats.AveragePathLength != 9.0/5 || stats.MinDegree != 2 || stats.MaxDegree != 2 {
+		t.Fatalf("unexpected path length or degrees %+v", stats)
+	}
+
+	romania, _ := BuildRomaniaGraph().Stats(StatsOptions{Weighted: true})
+	if romania.Diameter <= romania.Radius || romania.Radius == 0 {
+		t.Fatalf("expected weighted diameter above radius, got %d and %d", romania.Diameter, romania.Radius)
+	}
+
+	path := NewGraph(true)
+	path.AddEdge("A", "B", 1)
+	path.AddEdge("B", "C", 1)
+	stats, _ = path.Stats(StatsOptions{})
+	if stats.Connected || stats.Diameter != 2 || stats.Radius != 1 {
+		t.Fatalf("expected a disconnected directed path with diameter 2 and radius 1, got %+v", stats)
+	}
+
+	path.AddEdge("C", "A", -1)
+	if _, ok := path.Stats(StatsOptions{Weighted: true}); ok {
+		t.Fatal("expected weighted stats to reject negative weights")
+	}
+}
+
+func TestStatsApproximate(t *testing.T) {
+	grid, _ := GridGraph(30, 30, GeneratorOptions{})
+	exact, _ := grid.Stats(StatsOptions{})
+	approx, _ := grid.Stats(StatsOptions{Approximate: true, Samples: 8, Seed: 3})
+
+	if approx.Exact || approx.Diameter > exact.Diameter || approx.Radius < exact.Radius {
+		t.Fatalf("expected diameter lower bound and radius upper bound, got %+v", approx)
+	}
+	// The double sweep finds opposite corners of a grid.
+	if approx.Diameter != 58 {
+		t.Fatalf("expected double sweep to find diameter 58, got %d", approx.Diameter)
+	}
+	if math.Abs(approx.AveragePathLength-exact.AveragePathLength) > 3 {
+		t.Fatalf("expected sampled path length near %.2f, got %.2f", exact.AveragePathLength, approx.AveragePathLength)
+	}
+	if !approx.Connected || approx.Triangles != exact.Triangles {
+		t.Fatal("expected non-distance figures to stay exact")
+	}
+
+	small, _ := BuildRomaniaGraph().Stats(StatsOptions{Approximate: true, Samples: 50})
+	if !small.Exact {
+		t.Fatal("expected enough samples to give exact figures")
+	}
+}
+
+func TestStatsOutput(t *testing.T) {
+	stats, _ := karateClubGraph().Stats(StatsOptions{})
+	data, err := json.Marshal(stats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Stats
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Triangles != 45 || decoded.DegreeDistribution[17] != 1 || decoded.Diameter != 5 {
+		t.Fatalf("expected JSON round trip, got %+v", decoded)
+	}
+	if !strings.Contains(string(data), `"average_path_length"`) {
+		t.Fatal("expected snake_case JSON keys")
+	}
+
+	text := stats.String()
+	if !strings.Contains(text, "triangles:              45") || !strings.Contains(text, "  17: 1") {
+		t.Fatalf("unexpected report:\n%s", text)
+	}
+}
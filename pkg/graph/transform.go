@@ -0,0 +1,128 @@
+package graph
+
+// Clone returns a deep copy of the graph with the same vertices, edges,
+// weights, capacities and directedness. Changes to the copy do not affect the original.
+func (g *Graph) Clone() *Graph {
+	return g.EdgeSubgraph(func(*Edge) bool { return true })
+}
+
+// Transpose returns a copy of the graph with every directed edge reversed.
+// The transpose of an undirected graph is a copy of the graph.
+func (g *Graph) Transpose() *Graph {
+	if !g.directed {
+		return g.Clone()
+	}
+
+	t := NewGraph(true)
+	for id := range g.vertices {
+		t.AddVertex(id)
+	}
+	for _, edge := range g.GetEdges() {
+		t.addEdgeCopy(edge.to.id, edge.from.id, edge)
+	}
+	return t
+}
+
+// InducedSubgraph returns a new graph with the given vertices and every edge
+// of g whose endpoints are both among them. IDs that are not in g are ignored.
+func (g *Graph) InducedSubgraph(ids []string) *Graph {
+	sub := NewGraph(g.directed)
+	for _, id := range ids {
+		if g.HasVertex(id) {
+			sub.AddVertex(id)
+		}
+	}
+	for _, edge := range g.GetEdges() {
+		if sub.HasVertex(edge.from.id) && sub.HasVertex(edge.to.id) {
+			sub.addEdgeCopy(edge.from.id, edge.to.id, edge)
+		}
+	}
+	return sub
+}
+
+// EdgeSubgraph returns a new graph with every vertex of g and only the edges
+// for which keep returns true. In undirected graphs keep is called once per
+// edge pair. A nil keep keeps no edges.
+func (g *Graph) EdgeSubgraph(keep func(e *Edge) bool) *Graph {
+	sub := NewGraph(g.directed)
+	for id := range g.vertices {
+		sub.AddVertex(id)
+	}
+	if keep == nil {
+		return sub
+	}
+	for _, edge := range g.GetEdges() {
+		if keep(edge) {
+			sub.addEdgeCopy(edge.from.id, edge.to.id, edge)
+		}
+	}
+	return sub
+}
+
+// Union returns a new graph with the vertices and edges of both graphs.
+// When an edge exists in both, the weight and capacity from g are kept.
+// It returns (nil, false) when the graphs differ in directedness.
+func (g *Graph) Union(other *Graph) (*Graph, bool) {
+	if other == nil || g.directed != other.directed {
+		return nil, false
+	}
+
+	union := other.Clone()
+	for id := range g.vertices {
+		union.AddVertex(id)
+	}
+	for _, edge := range g.GetEdges() {
+		union.addEdgeCopy(edge.from.id, edge.to.id, edge)
+	}
+	return union, true
+}
+
+// Intersection returns a new graph with the vertices present in both graphs
+// and the edges present in both, keeping the weights and capacities from g.
+// It returns (nil, false) when the graphs differ in directedness.
+func (g *Graph) Intersection(other *Graph) (*Graph, bool) {
+	if other == nil || g.directed != other.directed {
+		return nil, false
+	}
+
+	intersection := NewGraph(g.directed)
+	for id := range g.vertices {
+		if other.HasVertex(id) {
+			intersection.AddVertex(id)
+		}
+	}
+	for _, edge := range g.GetEdges() {
+		if other.HasEdge(edge.from.id, edge.to.id) {
+			intersection.addEdgeCopy(edge.from.id, edge.to.id, edge)
+		}
+	}
+	return intersection, true
+}
+
+// Complement returns a new graph with the same vertices and an edge of the
+// given weight between every pair of distinct vertices that are not
+// connected in g. In directed graphs each direction is considered separately.
+func (g *Graph) Complement(weight int) *Graph {
+	complement := NewGraph(g.directed)
+	ids := g.sortedVertexIDs()
+	for _, id := range ids {
+		complement.AddVertex(id)
+	}
+	for i, from := range ids {
+		for j, to := range ids {
+			if i == j || (!g.directed && j < i) {
+				continue
+			}
+			if !g.HasEdge(from, to) {
+				complement.AddEdge(from, to, weight)
+			}
+		}
+	}
+	return complement
+}
+
+// addEdgeCopy adds an edge from -> to with the weight and capacity of edge.
+func (g *Graph) addEdgeCopy(from, to string, edge *Edge) {
+	g.AddEdge(from, to, edge.weight)
+	g.SetCapacity(from, to, edge.capacity)
+}
@@ -0,0 +1,164 @@
+package graph
+
+import "testing"
+
+func TestClone(t *testing.T) {
+	g := BuildRomaniaGraph()
+	clone := g.Clone()
+
+	if clone.IsDirected() != g.IsDirected() {
+		t.Fatal("expected clone to keep directedness")
+	}
+	if len(clone.GetVertices()) != 20 || len(clone.GetEdges()) != 23 {
+		t.Fatalf("expected 20 vertices and 23 edges, got %d and %d", len(clone.GetVertices()), len(clone.GetEdges()))
+	}
+	edge, ok := clone.GetEdge("Sibiu", "Arad")
+	if !ok || edge.Weight() != 140 {
+		t.Fatal("expected clone to keep weights in both directions")
+	}
+
+	clone.RemoveVertex("Arad")
+	clone.AddEdge("Sibiu", "Fagaras", 1)
+	if !g.HasVertex("Arad") {
+		t.Fatal("expected original to be unaffected by vertex removal on clone")
+	}
+	if edge, _ := g.GetEdge("Sibiu", "Fagaras"); edge.Weight() != 99 {
+		t.Fatal("expected original weight to be unaffected by clone")
+	}
+
+	isolated := NewGraph(true)
+	isolated.AddVertex("A")
+	if !isolated.Clone().HasVertex("A") {
+		t.Fatal("expected clone to keep isolated vertices")
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	g := NewGraph(true)
+	g.AddEdge("A", "B", 3)
+	g.AddEdge("B", "C", 4)
+	g.AddVertex("D")
+
+	transposed := g.Transpose()
+	if !transposed.IsDirected() {
+		t.Fatal("expected transpose to stay directed")
+	}
+	edge, ok := transposed.GetEdge("B", "A")
+	if !ok || edge.Weight() != 3 {
+		t.Fatal("expected reversed edge B->A with weight 3")
+	}
+	if transposed.HasEdge("A", "B") || !transposed.HasEdge("C", "B") {
+		t.Fatal("expected every edge to be reversed")
+	}
+	if !transposed.HasVertex("D") {
+		t.Fatal("expected isolated vertices to be kept")
+	}
+
+	undirected := BuildRomaniaGraph().Transpose()
+	if len(undirected.GetEdges()) != 23 {
+		t.Fatal("expected undirected transpose to equal the original")
+	}
+}
+
+func TestInducedSubgraph(t *testing.T) {
+	g := BuildRomaniaGraph()
+	sub := g.InducedSubgraph([]string{"Arad", "Sibiu", "Fagaras", "Bucharest", "Nowhere"})
+
+	if len(sub.GetVertices()) != 4 {
+		t.Fatalf("expected 4 vertices, got %d", len(sub.GetVertices()))
+	}
+	if len(sub.GetEdges()) != 3 {
+		t.Fatalf("expected 3 edges, got %d", len(sub.GetEdges()))
+	}
+	if _, cost, ok := sub.ShortestPath("Arad", "Bucharest"); !ok || cost != 450 {
+		t.Fatalf("expected route through Fagaras costing 450, got %d", cost)
+	}
+}
+
+func TestEdgeSubgraph(t *testing.T) {
+	g := BuildRomaniaGraph()
+	short := g.EdgeSubgraph(func(e *Edge) bool { return e.Weight() < 100 })
+
+	if len(short.GetVertices()) != 20 {
+		t.Fatal("expected every vertex to be kept")
+	}
+	for _, edge := range short.GetEdges() {
+		if edge.Weight() >= 100 {
+			t.Fatalf("unexpected edge with weight %d", edge.Weight())
+		}
+	}
+	if len(short.GetEdges()) != 13 {
+		t.Fatalf("expected 13 roads shorter than 100, got %d", len(short.GetEdges()))
+	}
+	if !short.HasEdge("Zerind", "Arad") {
+		t.Fatal("expected undirected edges to stay mirrored")
+	}
+
+	if len(g.EdgeSubgraph(nil).GetEdges()) != 0 {
+		t.Fatal("expected nil predicate to keep no edges")
+	}
+}
+
+func TestUnionAndIntersection(t *testing.T) {
+	a := NewGraph(true)
+	a.AddEdge("A", "B", 1)
+	a.AddEdge("B", "C", 2)
+
+	b := NewGraph(true)
+	b.AddEdge("B", "C", 5)
+	b.AddEdge("C", "D", 3)
+
+	union, ok := a.Union(b)
+	if !ok {
+		t.Fatal("expected Union to succeed")
+	}
+	if len(union.GetVertices()) != 4 || len(union.GetEdges()) != 3 {
+		t.Fatalf("expected 4 vertices and 3 edges, got %d and %d", len(union.GetVertices()), len(union.GetEdges()))
+	}
+	if edge, _ := union.GetEdge("B", "C"); edge.Weight() != 2 {
+		t.Fatalf("expected receiver weight 2 on shared edge, got %d", edge.Weight())
+	}
+
+	intersection, ok := a.Intersection(b)
+	if !ok {
+		t.Fatal("expected Intersection to succeed")
+	}
+	if len(intersection.GetVertices()) != 2 || len(intersection.GetEdges()) != 1 {
+		t.Fatalf("expected 2 vertices and 1 edge, got %d and %d", len(intersection.GetVertices()), len(intersection.GetEdges()))
+	}
+	if edge, ok := intersection.GetEdge("B", "C"); !ok || edge.Weight() != 2 {
+		t.Fatal("expected shared edge B->C with receiver weight")
+	}
+
+	if _, ok := a.Union(NewGraph(false)); ok {
+		t.Fatal("expected Union to reject mixed directedness")
+	}
+	if _, ok := a.Intersection(nil); ok {
+		t.Fatal("expected Intersection to reject nil graph")
+	}
+}
+
+func TestComplement(t *testing.T) {
+	g := NewGraph(false)
+	g.AddEdge("A", "B", 1)
+	g.AddVertex("C")
+
+	complement := g.Complement(7)
+	if complement.HasEdge("A", "B") {
+		t.Fatal("expected existing edge to be absent from complement")
+	}
+	edge, ok := complement.GetEdge("C", "A")
+	if !ok || edge.Weight() != 7 {
+		t.Fatal("expected complement edge C-A with weight 7")
+	}
+	if len(complement.GetEdges()) != 2 {
+		t.Fatalf("expected 2 complement edges, got %d", len(complement.GetEdges()))
+	}
+
+	directed := NewGraph(true)
+	directed.AddEdge("A", "B", 1)
+	directedComplement := directed.Complement(1)
+	if directedComplement.HasEdge("A", "B") || !directedComplement.HasEdge("B", "A") {
+		t.Fatal("expected directed complement to consider each direction")
+	}
+}
@@ -0,0 +1,439 @@
+package graph
+
+import "math"
+
+// heldKarpLimit is the largest number of cities TSPHeldKarp accepts.
+// Held-Karp needs O(2^n * n) memory, so larger instances use heuristics.
+const heldKarpLimit = 16
+
+// TSP returns a good tour of cities: the optimal one from TSPHeldKarp for up
+// to 16 cities, otherwise a Christofides tour (nearest neighbour for directed
+// graphs) refined with TSPLocalSearch.
+//
+// Tours are closed: they start at cities[0], visit every city exactly once and
+// return to cities[0], so the first and last entries are equal. Travel between
+// consecutive cities follows the shortest path in the graph (the metric
+// closure), so the graph does not need to be complete; ExpandTour returns the
+// underlying vertex-by-vertex route.
+//
+// All TSP solvers accept the cities to visit, or nil for all vertices, and
+// return (nil, 0, false) when a city is missing or repeated, when the graph
+// has negative-weight edges, or when some city cannot reach another.
+func (g *Graph) TSP(cities []string) ([]string, int, bool) {
+	inst, ok := g.newTSPInstance(cities)
+	if !ok {
+		return nil, 0, false
+	}
+	if len(inst.cities) <= heldKarpLimit {
+		return inst.result(inst.heldKarp())
+	}
+
+	var order []int
+	if inst.directed {
+		order = inst.nearestNeighbor()
+	} else {
+		order = inst.christofides()
+	}
+	return inst.result(inst.localSearch(order))
+}
+
+// TSPHeldKarp returns an optimal tour using the Held-Karp dynamic program in
+// O(2^n * n^2) time. It returns (nil, 0, false) for more than 16 cities.
+func (g *Graph) TSPHeldKarp(cities []string) ([]string, int, bool) {
+	inst, ok := g.newTSPInstance(cities)
+	if !ok || len(inst.cities) > heldKarpLimit {
+		return nil, 0, false
+	}
+	return inst.result(inst.heldKarp())
+}
+
+// TSPNearestNeighbor builds a tour by repeatedly travelling to the closest
+// unvisited city, in O(n^2) after the metric closure is computed.
+func (g *Graph) TSPNearestNeighbor(cities []string) ([]string, int, bool) {
+	inst, ok := g.newTSPInstance(cities)
+	if !ok {
+		return nil, 0, false
+	}
+	return inst.result(inst.nearestNeighbor())
+}
+
+// TSPChristofides builds a tour with the Christofides construction: a minimum
+// spanning tree, a minimum-weight matching of its odd-degree vertices, an
+// Eulerian circuit of their union and shortcuts past repeated cities.
+// The tour costs at most 1.5 times the optimum while the matching is exact
+// (up to 20 odd vertices); beyond that a greedy matching is used.
+// It returns (nil, 0, false) for directed graphs, whose distances are not
+// symmetric.
+func (g *Graph) TSPChristofides(cities []string) ([]string, int, bool) {
+	inst, ok := g.newTSPInstance(cities)
+	if !ok || inst.directed {
+		return nil, 0, false
+	}
+	return inst.result(inst.christofides())
+}
+
+// TSPLocalSearch improves a closed tour with 2-opt (reversing a section of
+// the tour) and Or-opt (moving a run of one to three cities elsewhere) until
+// neither finds an improving move. The tour must start and end at the same
+// city and visit every other city once; the start city stays in place.
+func (g *Graph) TSPLocalSearch(tour []string) ([]string, int, bool) {
+	if len(tour) < 2 || tour[0] != tour[len(tour)-1] {
+		return nil, 0, false
+	}
+
+	inst, ok := g.newTSPInstance(tour[:len(tour)-1])
+	if !ok {
+		return nil, 0, false
+	}
+	order := make([]int, len(inst.cities))
+	for i := range order {
+		order[i] = i
+	}
+	return inst.result(inst.localSearch(order))
+}
+
+// ExpandTour replaces every leg of a tour with the shortest path between its
+// endpoints, returning the vertex-by-vertex route and its cost.
+func (g *Graph) ExpandTour(tour []string) ([]string, int, bool) {
+	if len(tour) == 0 {
+		return nil, 0, false
+	}
+	if !g.HasVertex(tour[0]) {
+		return nil, 0, false
+	}
+
+	route := []string{tour[0]}
+	total := 0
+	for i := 0; i+1 < len(tour); i++ {
+		path, cost, ok := g.ShortestPath(tour[i], tour[i+1])
+		if !ok {
+			return nil, 0, false
+		}
+		route = append(route, path[1:]...)
+		if total, ok = addCost(total, cost); !ok {
+			return nil, 0, false
+		}
+	}
+	return route, total, true
+}
+
+// DistanceMatrix returns the metric closure the TSP solvers work on:
+// dist[i][j] is the cost of the shortest path from cities[i] to cities[j].
+// It accepts nil for all vertices in ID order and fails in the same cases
+// as TSP.
+func (g *Graph) DistanceMatrix(cities []string) ([][]int, bool) {
+	inst, ok := g.newTSPInstance(cities)
+	if !ok {
+		return nil, false
+	}
+	return inst.dist, true
+}
+
+// tspInstance is a traveling salesman problem over the metric closure of a
+// set of cities.
+type tspInstance struct {
+	cities   []string
+	dist     [][]int
+	directed bool
+}
+
+func (g *Graph) newTSPInstance(cities []string) (*tspInstance, bool) {
+	if cities == nil {
+		cities = g.sortedVertexIDs()
+	}
+	if len(cities) == 0 || g.hasNegativeWeightEdge() {
+		return nil, false
+	}
+
+	ids := g.sortedVertexIDs()
+	index := indexVertexIDs(ids)
+	seen := make(map[string]struct{}, len(cities))
+	for _, city := range cities {
+		if _, ok := index[city]; !ok {
+			return nil, false
+		}
+		if _, ok := seen[city]; ok {
+			return nil, false
+		}
+		seen[city] = struct{}{}
+	}
+
+	adj := g.indexedAdjacency(ids)
+	inst := &tspInstance{
+		cities:   append([]string(nil), cities...),
+		dist:     make([][]int, len(cities)),
+		directed: g.directed,
+	}
+	maxDist := 0
+	for i, city := range cities {
+		distances := singleSourceDistances(adj, index[city], true)
+		inst.dist[i] = make([]int, len(cities))
+		for j, other := range cities {
+			d := distances[index[other]]
+			if d < 0 {
+				return nil, false
+			}
+			inst.dist[i][j] = d
+			maxDist = max(maxDist, d)
+		}
+	}
+	// Tour costs and the 2-opt prefix sums stay below 4n times the longest
+	// distance; refuse instances where that bound does not fit in an int.
+	if maxDist > math.MaxInt/(4*len(cities)) {
+		return nil, false
+	}
+	return inst, true
+}
+
+// result converts a visiting order into a closed tour of city IDs.
+func (inst *tspInstance) result(order []int) ([]string, int, bool) {
+	tour := make([]string, 0, len(order)+1)
+	for _, city := range order {
+		tour = append(tour, inst.cities[city])
+	}
+	if len(order) > 1 {
+		tour = append(tour, inst.cities[order[0]])
+	}
+	return tour, inst.cost(order), true
+}
+
+func (inst *tspInstance) cost(order []int) int {
+	if len(order) < 2 {
+		return 0
+	}
+	total := 0
+	for i := range order {
+		total += inst.dist[order[i]][order[(i+1)%len(order)]]
+	}
+	return total
+}
+
+func (inst *tspInstance) heldKarp() []int {
+	n := len(inst.cities)
+	if n <= 2 {
+		order := make([]int, n)
+		for i := range order {
+			order[i] = i
+		}
+		return order
+	}
+
+	// best[mask][j]: cheapest path from city 0 through the cities in mask
+	// (bit k stands for city k+1), ending at city j+1.
+	m := n - 1
+	full := 1<<m - 1
+	best := make([][]int, full+1)
+	parent := make([][]int, full+1)
+	for mask := range best {
+		best[mask] = make([]int, m)
+		parent[mask] = make([]int, m)
+		for j := range best[mask] {
+			best[mask][j] = -1
+		}
+	}
+	for j := 0; j < m; j++ {
+		best[1<<j][j] = inst.dist[0][j+1]
+		parent[1<<j][j] = -1
+	}
+
+	for mask := 1; mask <= full; mask++ {
+		for j := 0; j < m; j++ {
+			if best[mask][j] < 0 {
+				continue
+			}
+			for k := 0; k < m; k++ {
+				if mask&(1<<k) != 0 {
+					continue
+				}
+				next := mask | 1<<k
+				candidate := best[mask][j] + inst.dist[j+1][k+1]
+				if best[next][k] < 0 || candidate < best[next][k] {
+					best[next][k] = candidate
+					parent[next][k] = j
+				}
+			}
+		}
+	}
+
+	last, bestCost := -1, 0
+	for j := 0; j < m; j++ {
+		candidate := best[full][j] + inst.dist[j+1][0]
+		if last < 0 || candidate < bestCost {
+			last, bestCost = j, candidate
+		}
+	}
+
+	order := make([]int, n)
+	for mask, j, pos := full, last, n-1; j >= 0; pos-- {
+		order[pos] = j + 1
+		mask, j = mask&^(1<<j), parent[mask][j]
+	}
+	return order
+}
+
+func (inst *tspInstance) nearestNeighbor() []int {
+	n := len(inst.cities)
+	visited := make([]bool, n)
+	order := make([]int, 1, n)
+	visited[0] = true
+
+	for len(order) < n {
+		current := order[len(order)-1]
+		next := -1
+		for j := 0; j < n; j++ {
+			if !visited[j] && (next < 0 || inst.dist[current][j] < inst.dist[current][next]) {
+				next = j
+			}
+		}
+		visited[next] = true
+		order = append(order, next)
+	}
+	return order
+}
+
+func (inst *tspInstance) christofides() []int {
+	n := len(inst.cities)
+	if n <= 2 {
+		return inst.nearestNeighbor()
+	}
+
+	m := &eulerGraph{incident: make([][]int, n)}
+
+	// Prim's algorithm on the complete metric closure.
+	inTree := make([]bool, n)
+	parent := make([]int, n)
+	key := make([]int, n)
+	for i := range key {
+		key[i] = -1
+		parent[i] = -1
+	}
+	key[0] = 0
+	for added := 0; added < n; added++ {
+		u := -1
+		for v := 0; v < n; v++ {
+			if !inTree[v] && key[v] >= 0 && (u < 0 || key[v] < key[u]) {
+				u = v
+			}
+		}
+		inTree[u] = true
+		if parent[u] >= 0 {
+			m.addEdge(parent[u], u)
+		}
+		for v := 0; v < n; v++ {
+			if !inTree[v] && (key[v] < 0 || inst.dist[u][v] < key[v]) {
+				key[v] = inst.dist[u][v]
+				parent[v] = u
+			}
+		}
+	}
+
+	var odd []int
+	for v, incident := range m.incident {
+		if len(incident)%2 == 1 {
+			odd = append(odd, v)
+		}
+	}
+	cost := make([][]int, len(odd))
+	for i, u := range odd {
+		cost[i] = make([]int, len(odd))
+		for j, v := range odd {
+			cost[i][j] = inst.dist[u][v]
+		}
+	}
+
+	var pairs [][2]int
+	if len(odd) <= exactMatchingLimit {
+		pairs = exactPerfectMatching(cost)
+	} else {
+		pairs = greedyPerfectMatching(cost)
+	}
+	for _, pair := range pairs {
+		m.addEdge(odd[pair[0]], odd[pair[1]])
+	}
+
+	visited := make([]bool, n)
+	order := make([]int, 0, n)
+	for _, step := range m.hierholzer(0) {
+		for _, v := range step {
+			if !visited[v] {
+				visited[v] = true
+				order = append(order, v)
+			}
+		}
+	}
+	return order
+}
+
+// localSearch applies improving 2-opt and Or-opt moves to order until it is
+// locally optimal. order[0] never moves.
+func (inst *tspInstance) localSearch(order []int) []int {
+	tour := append([]int(nil), order...)
+	if len(tour) < 4 {
+		return tour
+	}
+	for inst.twoOpt(tour) || inst.orOpt(tour) {
+	}
+	return tour
+}
+
+// twoOpt applies the first improving segment reversal it finds.
+// Prefix sums of the forward and backward leg costs make every move O(1),
+// including on asymmetric (directed) distances.
+func (inst *tspInstance) twoOpt(tour []int) bool {
+	n := len(tour)
+	forward := make([]int, n)
+	backward := make([]int, n)
+	for k := 1; k < n; k++ {
+		forward[k] = forward[k-1] + inst.dist[tour[k-1]][tour[k]]
+		backward[k] = backward[k-1] + inst.dist[tour[k]][tour[k-1]]
+	}
+
+	for i := 1; i < n-1; i++ {
+		for j := i + 1; j < n; j++ {
+			a, b := tour[i-1], tour[(j+1)%n]
+			delta := inst.dist[a][tour[j]] + inst.dist[tour[i]][b] -
+				inst.dist[a][tour[i]] - inst.dist[tour[j]][b] +
+				(backward[j] - backward[i]) - (forward[j] - forward[i])
+			if delta < 0 {
+				for l, r := i, j; l < r; l, r = l+1, r-1 {
+					tour[l], tour[r] = tour[r], tour[l]
+				}
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// orOpt applies the first improving move of a run of one to three
+// consecutive cities to another position in the tour.
+func (inst *tspInstance) orOpt(tour []int) bool {
+	n := len(tour)
+	for length := 1; length <= 3 && length < n-1; length++ {
+		for i := 1; i+length <= n; i++ {
+			first, last := tour[i], tour[i+length-1]
+			a, b := tour[i-1], tour[(i+length)%n]
+			removed := inst.dist[a][b] - inst.dist[a][first] - inst.dist[last][b]
+
+			rest := make([]int, 0, n-length)
+			rest = append(rest, tour[:i]...)
+			rest = append(rest, tour[i+length:]...)
+			for p := range rest {
+				p2, q := rest[p], rest[(p+1)%len(rest)]
+				if p == i-1 {
+					continue
+				}
+				delta := removed + inst.dist[p2][first] + inst.dist[last][q] - inst.dist[p2][q]
+				if delta < 0 {
+					result := make([]int, 0, n)
+					result = append(result, rest[:p+1]...)
+					result = append(result, tour[i:i+length]...)
+					result = append(result, rest[p+1:]...)
+					copy(tour, result)
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+package graph
+
+import "testing"
+
+// bruteForceTSP returns the optimal tour cost by trying every permutation of
+// cities[1:].
+func bruteForceTSP(g *Graph, cities []string) int {
+	rest := append([]string(nil), cities[1:]...)
+	best := -1
+
+	var permute func(k int)
+	permute = func(k int) {
+		if k == len(rest) {
+			tour := append(append([]string{cities[0]}, rest...), cities[0])
+			_, cost, _ := g.ExpandTour(tour)
+			if best < 0 || cost < best {
+				best = cost
+			}
+			return
+		}
+		for i := k; i < len(rest); i++ {
+			rest[k], rest[i] = rest[i], rest[k]
+			permute(k + 1)
+			rest[k], rest[i] = rest[i], rest[k]
+		}
+	}
+	permute(0)
+	return best
+}
+
+// checkTour verifies that tour is closed, visits every city once and costs cost.
+func checkTour(t *testing.T, g *Graph, cities, tour []string, cost int) {
+	t.Helper()
+
+	if len(tour) != len(cities)+1 || tour[0] != cities[0] || tour[len(tour)-1] != cities[0] {
+		t.Fatalf("expected closed tour from %s over %d cities, got %v", cities[0], len(cities), tour)
+	}
+	seen := make(map[string]bool)
+	for _, city := range tour[1:] {
+		if seen[city] {
+			t.Fatalf("city %s visited twice in %v", city, tour)
+		}
+		seen[city] = true
+	}
+	if _, expanded, ok := g.ExpandTour(tour); !ok || expanded != cost {
+		t.Fatalf("expected tour cost %d to match expanded route cost %d", cost, expanded)
+	}
+}
+
+func TestTSPHeldKarpMatchesBruteForce(t *testing.T) {
+	g := BuildRomaniaGraph()
+	cities := []string{"Arad", "Bucharest", "Craiova", "Iasi", "Oradea", "Sibiu", "Timisoara"}
+
+	tour, cost, ok := g.TSPHeldKarp(cities)
+	if !ok {
+		t.Fatal("expected TSPHeldKarp to succeed")
+	}
+	checkTour(t, g, cities, tour, cost)
+	if expected := bruteForceTSP(g, cities); cost != expected {
+		t.Fatalf("expected optimal cost %d, got %d", expected, cost)
+	}
+
+	if _, _, ok := g.TSPHeldKarp(nil); ok {
+		t.Fatal("expected TSPHeldKarp to reject more than 16 cities")
+	}
+}
+
+func TestTSPHeuristics(t *testing.T) {
+	g := BuildRomaniaGraph()
+	cities := []string{"Arad", "Bucharest", "Craiova", "Iasi", "Oradea", "Sibiu", "Timisoara", "Eforie"}
+	_, optimal, _ := g.TSPHeldKarp(cities)
+
+	nnTour, nnCost, ok := g.TSPNearestNeighbor(cities)
+	if !ok {
+		t.Fatal("expected TSPNearestNeighbor to succeed")
+	}
+	checkTour(t, g, cities, nnTour, nnCost)
+
+	christofidesTour, christofidesCost, ok := g.TSPChristofides(cities)
+	if !ok {
+		t.Fatal("expected TSPChristofides to succeed")
+	}
+	checkTour(t, g, cities, christofidesTour, christofidesCost)
+	if 2*christofidesCost > 3*optimal {
+		t.Fatalf("expected Christofides cost %d within 1.5x of optimum %d", christofidesCost, optimal)
+	}
+
+	for _, tour := range [][]string{nnTour, christofidesTour} {
+		improvedTour, improvedCost, ok := g.TSPLocalSearch(tour)
+		if !ok {
+			t.Fatal("expected TSPLocalSearch to succeed")
+		}
+		checkTour(t, g, cities, improvedTour, improvedCost)
+		_, before, _ := g.ExpandTour(tour)
+		if improvedCost > before || improvedCost < optimal {
+			t.Fatalf("expected local search cost %d between optimum %d and start %d", improvedCost, optimal, before)
+		}
+	}
+}
+
+func TestTSPAllRomania(t *testing.T) {
+	g := BuildRomaniaGraph()
+
+	tour, cost, ok := g.TSP(nil)
+	if !ok {
+		t.Fatal("expected TSP to succeed on all 20 cities")
+	}
+	checkTour(t, g, g.sortedVertexIDs(), tour, cost)
+
+	route, routeCost, ok := g.ExpandTour(tour)
+	if !ok || routeCost != cost {
+		t.Fatalf("expected expanded route cost %d, got %d", cost, routeCost)
+	}
+	for i := 0; i+1 < len(route); i++ {
+		if !g.HasEdge(route[i], route[i+1]) {
+			t.Fatalf("expected consecutive route vertices to be adjacent: %s, %s", route[i], route[i+1])
+		}
+	}
+}
+
+func TestTSPDirected(t *testing.T) {
+	g := NewGraph(true)
+	g.AddEdge("A", "B", 1)
+	g.AddEdge("B", "C", 1)
+	g.AddEdge("C", "D", 1)
+	g.AddEdge("D", "A", 1)
+	g.AddEdge("A", "C", 1)
+	g.AddEdge("C", "B", 10)
+	g.AddEdge("B", "D", 10)
+
+	cities := []string{"A", "B", "C", "D"}
+	tour, cost, ok := g.TSP(cities)
+	if !ok || cost != 4 {
+		t.Fatalf("expected directed tour cost 4, got %d (ok=%t)", cost, ok)
+	}
+	checkTour(t, g, cities, tour, cost)
+
+	if _, _, ok := g.TSPChristofides(cities); ok {
+		t.Fatal("expected TSPChristofides to reject directed graphs")
+	}
+
+	improved, improvedCost, ok := g.TSPLocalSearch([]string{"A", "C", "B", "D", "A"})
+	if !ok || improvedCost > 22 {
+		t.Fatalf("expected local search not to worsen the tour, got %v cost %d", improved, improvedCost)
+	}
+}
+
+func TestTSPFailureCases(t *testing.T) {
+	g := BuildRomaniaGraph()
+
+	if _, _, ok := g.TSP([]string{"Arad", "Nowhere"}); ok {
+		t.Error("expected TSP to fail for missing city")
+	}
+	if _, _, ok := g.TSP([]string{"Arad", "Arad"}); ok {
+		t.Error("expected TSP to fail for repeated city")
+	}
+	if _, _, ok := g.TSPLocalSearch([]string{"Arad", "Sibiu"}); ok {
+		t.Error("expected TSPLocalSearch to reject open tour")
+	}
+
+	g.AddVertex("Island")
+	if _, _, ok := g.TSP([]string{"Arad", "Island"}); ok {
+		t.Error("expected TSP to fail for unreachable city")
+	}
+
+	tour, cost, ok := g.TSP([]string{"Arad"})
+	if !ok || cost != 0 || len(tour) != 1 {
+		t.Errorf("expected single-city tour, got %v cost %d", tour, cost)
+	}
+}
+
+func TestDistanceMatrix(t *testing.T) {
+	g := BuildRomaniaGraph()
+	dist, ok := g.DistanceMatrix([]string{"Arad", "Bucharest", "Sibiu"})
+	if !ok || dist[0][1] != 418 || dist[1][0] != 418 || dist[0][2] != 140 || dist[2][2] != 0 {
+		t.Fatalf("expected shortest-path distances, got %v", dist)
+	}
+	if _, ok := g.DistanceMatrix([]string{"Arad", "Nowhere"}); ok {
+		t.Fatal("expected unknown cities to be rejected")
+	}
+}
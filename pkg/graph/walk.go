@@ -0,0 +1,325 @@
+package graph
+
+import (
+	"iter"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// AliasTable samples indices in proportion to fixed non-negative weights in
+// O(1) time per sample, using Vose's alias method. Building it takes O(n).
+// An AliasTable is immutable and safe for concurrent use with separate
+// random sources.
+type AliasTable struct {
+	prob  []float64
+	alias []int
+}
+
+// NewAliasTable builds an alias table over weights. Index i is sampled with
+// probability weights[i] / sum(weights). It returns false when weights is
+// empty, contains a negative, NaN or infinite weight, or sums to zero.
+func NewAliasTable(weights []float64) (*AliasTable, bool) {
+	n := len(weights)
+	total := 0.0
+	for _, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, false
+		}
+		total += w
+	}
+	if n == 0 || total == 0 || math.IsInf(total, 0) {
+		return nil, false
+	}
+
+	t := &AliasTable{prob: make([]float64, n), alias: make([]int, n)}
+	scaled := make([]float64, n)
+	var small, large []int
+	positive := -1
+	for i, w := range weights {
+		if w > 0 && positive < 0 {
+			positive = i
+		}
+		scaled[i] = w * float64(n) / total
+		t.alias[i] = i
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small = small[:len(small)-1]
+		t.prob[s], t.alias[s] = scaled[s], l
+		scaled[l] -= 1 - scaled[s]
+		if scaled[l] < 1 {
+			large = large[:len(large)-1]
+			small = append(small, l)
+		}
+	}
+	// Whatever is left is 1 up to rounding error, except that a zero weight
+	// must never be sampled, so it defers to a positive one instead.
+	for _, i := range append(small, large...) {
+		if weights[i] == 0 {
+			t.prob[i], t.alias[i] = 0, positive
+		} else {
+			t.prob[i] = 1
+		}
+	}
+	return t, true
+}
+
+// Len returns the number of weights the table was built from.
+func (t *AliasTable) Len() int {
+	return len(t.prob)
+}
+
+// Sample returns a random index drawn from r.
+func (t *AliasTable) Sample(r *rand.Rand) int {
+	i := r.Intn(len(t.prob))
+	if r.Float64() < t.prob[i] {
+		return i
+	}
+	return t.alias[i]
+}
+
+// Walker samples random walks over a snapshot of a graph. It precomputes an
+// alias table per vertex over the weights of its outbound edges, so every
+// weighted step takes O(1) time however large the degree. Walks follow edge
+// direction in directed graphs.
+//
+// A Walker is immutable and safe for concurrent use; every iterator it
+// returns draws from its own random source.
+type Walker struct {
+	ids    []string
+	index  map[string]int
+	adj    [][]indexedEdge // sorted by target
+	tables []*AliasTable   // nil when a vertex has no edge of positive weight
+}
+
+// WalkOptions configures the walks of a Walker.
+type WalkOptions struct {
+	// Seed for the random source; equal seeds give equal walks.
+	Seed int64
+	// Length is the maximum number of vertices in a walk, including its
+	// start; values <= 0 use 80. A walk ends early at a vertex it cannot
+	// leave.
+	Length int
+	// WalksPerVertex is the number of walks started from each start vertex;
+	// values <= 0 use 10.
+	WalksPerVertex int
+	// Starts lists the start vertices; nil starts from every vertex.
+	Starts []string
+	// Weighted picks each step in proportion to edge weight. By default every
+	// neighbour is equally likely. Edges of weight 0 are never taken by a
+	// weighted walk.
+	Weighted bool
+}
+
+// Walker returns a Walker over the vertices and edges the graph has now.
+// Later changes to the graph do not affect it. It returns false if the graph
+// has negative-weight edges.
+func (g *Graph) Walker() (*Walker, bool) {
+	ids := g.sortedVertexIDs()
+	w := &Walker{
+		ids:    ids,
+		index:  indexVertexIDs(ids),
+		adj:    g.indexedAdjacency(ids),
+		tables: make([]*AliasTable, len(ids)),
+	}
+	for u, list := range w.adj {
+		weights := make([]float64, len(list))
+		for i, edge := range list {
+			if edge.weight < 0 {
+				return nil, false
+			}
+			weights[i] = float64(edge.weight)
+		}
+		w.tables[u], _ = NewAliasTable(weights)
+	}
+	return w, true
+}
+
+// Walks returns an iterator over random walks: WalksPerVertex rounds, each
+// starting one walk from every start vertex in a shuffled order, as in
+// DeepWalk. Every step moves to a random neighbour of the current vertex.
+// It returns false if a start vertex does not exist.
+func (w *Walker) Walks(opts WalkOptions) (iter.Seq[[]string], bool) {
+	return w.walks(opts, func(walk []int, r *rand.Rand) (int, bool) {
+		return w.step(walk[len(walk)-1], r, opts.Weighted)
+	})
+}
+
+// Node2VecWalks returns an iterator over the second-order walks of node2vec,
+// started like those of Walks. After moving from t to v, the walk steps to
+// a neighbour x of v with probability proportional to the edge weight (or 1
+// when not Weighted) times a bias: 1/p if x is t, 1 if x is a neighbour of
+// t, and 1/q otherwise. A low p keeps the walk local, and a low q pushes it
+// outward. Steps are drawn from the first-order alias tables by rejection
+// sampling, so no per-edge tables are needed. It returns false if p or q is
+// not positive or a start vertex does not exist.
+func (w *Walker) Node2VecWalks(p, q float64, opts WalkOptions) (iter.Seq[[]string], bool) {
+	if !(p > 0) || !(q > 0) || math.IsInf(p, 0) || math.IsInf(q, 0) {
+		return nil, false
+	}
+	upper := max(1/p, 1, 1/q)
+	return w.walks(opts, func(walk []int, r *rand.Rand) (int, bool) {
+		v := walk[len(walk)-1]
+		if len(walk) == 1 {
+			return w.step(v, r, opts.Weighted)
+		}
+		t := walk[len(walk)-2]
+		for {
+			x, ok := w.step(v, r, opts.Weighted)
+			if !ok {
+				return 0, false
+			}
+			bias := 1 / q
+			if x == t {
+				bias = 1 / p
+			} else if w.adjacent(t, x) {
+				bias = 1
+			}
+			if r.Float64()*upper < bias {
+				return x, true
+			}
+		}
+	})
+}
+
+// RestartWalks returns an iterator over random walks with restart, started
+// like those of Walks. Before each step the walk jumps back to its start
+// vertex with probability alpha, and it also jumps back when it reaches a
+// vertex it cannot leave. The visit frequencies of such walks estimate
+// personalized PageRank around the start vertex. It returns false if alpha
+// is outside [0, 1) or a start vertex does not exist.
+func (w *Walker) RestartWalks(alpha float64, opts WalkOptions) (iter.Seq[[]string], bool) {
+	if !(alpha >= 0 && alpha < 1) {
+		return nil, false
+	}
+	return w.walks(opts, func(walk []int, r *rand.Rand) (int, bool) {
+		start, v := walk[0], walk[len(walk)-1]
+		if v != start && r.Float64() < alpha {
+			return start, true
+		}
+		if next, ok := w.step(v, r, opts.Weighted); ok {
+			return next, true
+		}
+		return start, v != start
+	})
+}
+
+// SampleNeighbors returns an iterator that yields, for each vertex in ids,
+// up to k of its neighbours sampled uniformly without replacement, as in
+// GraphSAGE. Vertices with at most k neighbours yield all of them, in a
+// random order. Equal seeds give equal samples. It returns false if k is
+// negative or a vertex does not exist.
+func (w *Walker) SampleNeighbors(ids []string, k int, seed int64) (iter.Seq2[string, []string], bool) {
+	if k < 0 {
+		return nil, false
+	}
+	for _, id := range ids {
+		if _, ok := w.index[id]; !ok {
+			return nil, false
+		}
+	}
+	ids = append([]string(nil), ids...)
+	return func(yield func(string, []string) bool) {
+		r := rand.New(rand.NewSource(seed))
+		for _, id := range ids {
+			list := w.adj[w.index[id]]
+			order := make([]int, len(list))
+			for i := range order {
+				order[i] = i
+			}
+			n := min(k, len(list))
+			sample := make([]string, n)
+			for i := 0; i < n; i++ {
+				j := i + r.Intn(len(order)-i)
+				order[i], order[j] = order[j], order[i]
+				sample[i] = w.ids[list[order[i]].to]
+			}
+			if !yield(id, sample) {
+				return
+			}
+		}
+	}, true
+}
+
+// walks resolves the options and returns an iterator over walks grown by
+// next, which returns the vertex after walk or false to end it.
+func (w *Walker) walks(opts WalkOptions, next func(walk []int, r *rand.Rand) (int, bool)) (iter.Seq[[]string], bool) {
+	length := opts.Length
+	if length <= 0 {
+		length = 80
+	}
+	rounds := opts.WalksPerVertex
+	if rounds <= 0 {
+		rounds = 10
+	}
+	var starts []int
+	if opts.Starts == nil {
+		starts = make([]int, len(w.ids))
+		for i := range starts {
+			starts[i] = i
+		}
+	} else {
+		for _, id := range opts.Starts {
+			u, ok := w.index[id]
+			if !ok {
+				return nil, false
+			}
+			starts = append(starts, u)
+		}
+	}
+
+	return func(yield func([]string) bool) {
+		r := rand.New(rand.NewSource(opts.Seed))
+		order := append([]int(nil), starts...)
+		walk := make([]int, 0, length)
+		for round := 0; round < rounds; round++ {
+			r.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+			for _, start := range order {
+				walk = append(walk[:0], start)
+				for len(walk) < length {
+					v, ok := next(walk, r)
+					if !ok {
+						break
+					}
+					walk = append(walk, v)
+				}
+				path := make([]string, len(walk))
+				for i, u := range walk {
+					path[i] = w.ids[u]
+				}
+				if !yield(path) {
+					return
+				}
+			}
+		}
+	}, true
+}
+
+// step moves from u to a random neighbour, or returns false if u has none
+// (no edge of positive weight when weighted).
+func (w *Walker) step(u int, r *rand.Rand, weighted bool) (int, bool) {
+	list := w.adj[u]
+	if weighted {
+		if w.tables[u] == nil {
+			return 0, false
+		}
+		return list[w.tables[u].Sample(r)].to, true
+	}
+	if len(list) == 0 {
+		return 0, false
+	}
+	return list[r.Intn(len(list))].to, true
+}
+
+// adjacent reports whether the edge u -> v exists.
+func (w *Walker) adjacent(u, v int) bool {
+	list := w.adj[u]
+	i := sort.Search(len(list), func(i int) bool { return list[i].to >= v })
+	return i < len(list) && list[i].to == v
+}
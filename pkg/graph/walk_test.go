@@ -0,0 +1,283 @@
+package graph
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestAliasTable(t *testing.T) {
+	weights := []float64{1, 2, 3, 0, 4}
+	table, ok := NewAliasTable(weights)
+	if !ok || table.Len() != len(weights) {
+		t.Fatal("expected the alias table to be built")
+	}
+
+	r := rand.New(rand.NewSource(1))
+	const samples = 100000
+	counts := make([]int, len(weights))
+	for i := 0; i < samples; i++ {
+		counts[table.Sample(r)]++
+	}
+	for i, w := range weights {
+		want := w / 10
+		if got := float64(counts[i]) / samples; math.Abs(got-want) > 0.01 {
+			t.Errorf("index %d: expected frequency %.2f, got %.3f", i, want, got)
+		}
+	}
+
+	// Zero weights must never be reachable, however rounding plays out.
+	for trial := 0; trial < 1000; trial++ {
+		weights := make([]float64, 2+r.Intn(30))
+		for i := range weights {
+			if r.Intn(3) > 0 {
+				weights[i] = r.Float64()
+			}
+		}
+		table, ok := NewAliasTable(weights)
+		if !ok {
+			continue
+		}
+		for i, w := range weights {
+			if w == 0 && table.prob[i] > 0 {
+				t.Fatalf("zero weight %d of %v can be sampled directly", i, weights)
+			}
+			if weights[table.alias[i]] == 0 && table.prob[i] < 1 {
+				t.Fatalf("index %d of %v aliases a zero weight", i, weights)
+			}
+		}
+	}
+
+	for _, bad := range [][]float64{nil, {0, 0}, {1, -1}, {math.NaN()}, {math.Inf(1)}} {
+		if _, ok := NewAliasTable(bad); ok {
+			t.Errorf("expected %v to be rejected", bad)
+		}
+	}
+}
+
+// checkRandomWalk fails unless every step of walk follows an edge of g.
+func checkRandomWalk(t *testing.T, g *Graph, walk []string) {
+	t.Helper()
+	for i := 1; i < len(walk); i++ {
+		if _, ok := g.GetEdge(walk[i-1], walk[i]); !ok {
+			t.Fatalf("walk %v steps along missing edge %s -> %s", walk, walk[i-1], walk[i])
+		}
+	}
+}
+
+func TestWalks(t *testing.T) {
+	g := BuildRomaniaGraph()
+	w, ok := g.Walker()
+	if !ok {
+		t.Fatal("expected Walker to succeed")
+	}
+
+	opts := WalkOptions{Seed: 3, Length: 12, WalksPerVertex: 2}
+	walks, ok := w.Walks(opts)
+	if !ok {
+		t.Fatal("expected Walks to succeed")
+	}
+	var all [][]string
+	starts := make(map[string]int)
+	for walk := range walks {
+		if len(walk) != 12 {
+			t.Fatalf("expected walks of 12 vertices, got %v", walk)
+		}
+		checkRandomWalk(t, g, walk)
+		starts[walk[0]]++
+		all = append(all, walk)
+	}
+	if len(all) != 2*len(g.GetVertices()) {
+		t.Fatalf("expected two walks per vertex, got %d walks", len(all))
+	}
+	for id, n := range starts {
+		if n != 2 {
+			t.Errorf("expected two walks from %s, got %d", id, n)
+		}
+	}
+
+	var again [][]string
+	for walk := range walks {
+		again = append(again, walk)
+	}
+	if !reflect.DeepEqual(all, again) {
+		t.Fatal("expected ranging twice with one seed to give equal walks")
+	}
+
+	if _, ok := w.Walks(WalkOptions{Starts: []string{"Atlantis"}}); ok {
+		t.Fatal("expected unknown start vertices to be rejected")
+	}
+}
+
+func TestWalksEndAtDeadEnds(t *testing.T) {
+	g := NewGraph(true)
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("b", "c", 0)
+	w, _ := g.Walker()
+
+	walks, _ := w.Walks(WalkOptions{Starts: []string{"a"}, WalksPerVertex: 1})
+	for walk := range walks {
+		if !reflect.DeepEqual(walk, []string{"a", "b", "c"}) {
+			t.Fatalf("expected [a b c], got %v", walk)
+		}
+	}
+	// A weighted walk never takes the zero-weight edge.
+	walks, _ = w.Walks(WalkOptions{Starts: []string{"a"}, WalksPerVertex: 1, Weighted: true})
+	for walk := range walks {
+		if !reflect.DeepEqual(walk, []string{"a", "b"}) {
+			t.Fatalf("expected [a b], got %v", walk)
+		}
+	}
+
+	g.AddEdge("c", "a", -1)
+	if _, ok := g.Walker(); ok {
+		t.Fatal("expected negative weights to be rejected")
+	}
+}
+
+func TestWeightedWalks(t *testing.T) {
+	g := NewGraph(true)
+	g.AddEdge("hub", "heavy", 9)
+	g.AddEdge("hub", "light", 1)
+	w, _ := g.Walker()
+
+	walks, _ := w.Walks(WalkOptions{Length: 2, WalksPerVertex: 10000, Starts: []string{"hub"}, Weighted: true})
+	heavy := 0
+	for walk := range walks {
+		if walk[1] == "heavy" {
+			heavy++
+		}
+	}
+	if heavy < 8800 || heavy > 9200 {
+		t.Fatalf("expected about 9000 steps to heavy, got %d", heavy)
+	}
+}
+
+// backtracks counts the steps of node2vec walks on a cycle that return to
+// the previous vertex.
+func backtracks(t *testing.T, p, q float64) int {
+	t.Helper()
+	g := buildCycleGraph(8)
+	w, _ := g.Walker()
+	walks, ok := w.Node2VecWalks(p, q, WalkOptions{Seed: 1, Length: 20})
+	if !ok {
+		t.Fatal("expected Node2VecWalks to succeed")
+	}
+	count := 0
+	for walk := range walks {
+		checkRandomWalk(t, g, walk)
+		for i := 2; i < len(walk); i++ {
+			if walk[i] == walk[i-2] {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func TestNode2VecWalks(t *testing.T) {
+	// 80 walks of 18 biased steps each.
+	const steps = 1440
+	if n := backtracks(t, 1, 1); n < steps*4/10 || n > steps*6/10 {
+		t.Errorf("expected unbiased walks to backtrack about half the time, got %d of %d", n, steps)
+	}
+	if n := backtracks(t, 0.01, 1); n < steps*95/100 {
+		t.Errorf("expected a low p to keep walks backtracking, got %d of %d", n, steps)
+	}
+	if n := backtracks(t, 100, 0.01); n > steps*5/100 {
+		t.Errorf("expected a low q to push walks outward, got %d of %d backtracks", n, steps)
+	}
+
+	w, _ := BuildRomaniaGraph().Walker()
+	for _, pq := range [][2]float64{{0, 1}, {1, -1}, {math.NaN(), 1}, {1, math.Inf(1)}} {
+		if _, ok := w.Node2VecWalks(pq[0], pq[1], WalkOptions{}); ok {
+			t.Errorf("expected p=%v q=%v to be rejected", pq[0], pq[1])
+		}
+	}
+}
+
+func TestRestartWalks(t *testing.T) {
+	g := NewGraph(true)
+	g.AddEdge("s", "a", 1)
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("b", "c", 1)
+	w, _ := g.Walker()
+
+	walks, ok := w.RestartWalks(0.5, WalkOptions{Seed: 2, Length: 30, Starts: []string{"s"}})
+	if !ok {
+		t.Fatal("expected RestartWalks to succeed")
+	}
+	restarts := 0
+	for walk := range walks {
+		if len(walk) != 30 || walk[0] != "s" {
+			t.Fatalf("expected 30 vertices from s, got %v", walk)
+		}
+		for i := 1; i < len(walk); i++ {
+			if walk[i] == "s" {
+				restarts++
+				continue
+			}
+			if _, ok := g.GetEdge(walk[i-1], walk[i]); !ok {
+				t.Fatalf("walk %v steps along missing edge %s -> %s", walk, walk[i-1], walk[i])
+			}
+		}
+	}
+	if restarts == 0 {
+		t.Fatal("expected walks to restart")
+	}
+
+	// Without restarts a walk still returns from the dead end at c.
+	walks, _ = w.RestartWalks(0, WalkOptions{Length: 6, WalksPerVertex: 1, Starts: []string{"s"}})
+	for walk := range walks {
+		if !reflect.DeepEqual(walk, []string{"s", "a", "b", "c", "s", "a"}) {
+			t.Fatalf("expected [s a b c s a], got %v", walk)
+		}
+	}
+	// A start vertex without edges gives a single-vertex walk.
+	walks, _ = w.RestartWalks(0.2, WalkOptions{WalksPerVertex: 1, Starts: []string{"c"}})
+	for walk := range walks {
+		if !reflect.DeepEqual(walk, []string{"c"}) {
+			t.Fatalf("expected [c], got %v", walk)
+		}
+	}
+
+	if _, ok := w.RestartWalks(1, WalkOptions{}); ok {
+		t.Fatal("expected alpha = 1 to be rejected")
+	}
+}
+
+func TestSampleNeighbors(t *testing.T) {
+	g := BuildRomaniaGraph()
+	w, _ := g.Walker()
+
+	samples, ok := w.SampleNeighbors([]string{"Bucharest", "Neamt", "Sibiu"}, 3, 4)
+	if !ok {
+		t.Fatal("expected SampleNeighbors to succeed")
+	}
+	sizes := map[string]int{"Bucharest": 3, "Neamt": 1, "Sibiu": 3}
+	visited := 0
+	for id, sample := range samples {
+		visited++
+		if len(sample) != sizes[id] {
+			t.Fatalf("expected %d neighbours of %s, got %v", sizes[id], id, sample)
+		}
+		seen := make(map[string]bool)
+		for _, neighbor := range sample {
+			if _, ok := g.GetEdge(id, neighbor); !ok || seen[neighbor] {
+				t.Fatalf("expected distinct neighbours of %s, got %v", id, sample)
+			}
+			seen[neighbor] = true
+		}
+	}
+	if visited != 3 {
+		t.Fatalf("expected three samples, got %d", visited)
+	}
+
+	if _, ok := w.SampleNeighbors([]string{"Atlantis"}, 1, 0); ok {
+		t.Fatal("expected unknown vertices to be rejected")
+	}
+	if _, ok := w.SampleNeighbors(nil, -1, 0); ok {
+		t.Fatal("expected a negative k to be rejected")
+	}
+}
@@ -0,0 +1,218 @@
+package metaheuristic
+
+import (
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/JeanGrijp/go-datastructures/pkg/graph"
+)
+
+// TSP returns the traveling salesman problem over cities of g, or over every
+// vertex in ID order when cities is nil. Distances are shortest-path costs
+// from g.DistanceMatrix, so the graph does not need to be complete.
+//
+// Solutions are visiting orders starting at cities[0]; the cost includes the
+// leg back to cities[0], and append(order, order[0]) gives the closed tour
+// format of graph.TSP. Neighbor reverses a random section of the order (a
+// 2-opt move) and Crossover is order crossover (OX1). It returns false when
+// DistanceMatrix does.
+func TSP(g *graph.Graph, cities []string) (Problem[[]string], bool) {
+	if g == nil {
+		return Problem[[]string]{}, false
+	}
+	if cities == nil {
+		for _, v := range g.GetVertices() {
+			cities = append(cities, v.ID())
+		}
+		sort.Strings(cities)
+	}
+	dist, ok := g.DistanceMatrix(cities)
+	if !ok {
+		return Problem[[]string]{}, false
+	}
+	cities = append([]string(nil), cities...)
+	index := make(map[string]int, len(cities))
+	for i, city := range cities {
+		index[city] = i
+	}
+
+	return Problem[[]string]{
+		Initial: func(r *rand.Rand) []string {
+			order := append([]string(nil), cities...)
+			r.Shuffle(len(order)-1, func(i, j int) { order[i+1], order[j+1] = order[j+1], order[i+1] })
+			return order
+		},
+		Cost: func(order []string) float64 {
+			total := 0
+			for i := range order {
+				total += dist[index[order[i]]][index[order[(i+1)%len(order)]]]
+			}
+			return float64(total)
+		},
+		Neighbor: func(order []string, r *rand.Rand) []string {
+			next := append([]string(nil), order...)
+			if len(next) < 4 {
+				return next
+			}
+			i, j := 1+r.Intn(len(next)-1), 1+r.Intn(len(next)-1)
+			if i > j {
+				i, j = j, i
+			}
+			for ; i < j; i, j = i+1, j-1 {
+				next[i], next[j] = next[j], next[i]
+			}
+			return next
+		},
+		Crossover: func(a, b []string, r *rand.Rand) []string {
+			n := len(a)
+			if n < 3 {
+				return append([]string(nil), a...)
+			}
+			// Keep a[i..j] in place and fill the other positions with the
+			// remaining cities in the order they appear in b.
+			i, j := 1+r.Intn(n-1), 1+r.Intn(n-1)
+			if i > j {
+				i, j = j, i
+			}
+			child := make([]string, n)
+			used := map[string]bool{a[0]: true}
+			child[0] = a[0]
+			for k := i; k <= j; k++ {
+				child[k] = a[k]
+				used[a[k]] = true
+			}
+			k := 1
+			for _, city := range b[1:] {
+				if used[city] {
+					continue
+				}
+				if k == i {
+					k = j + 1
+				}
+				child[k] = city
+				k++
+			}
+			return child
+		},
+		Key: func(order []string) string {
+			return strings.Join(order, "\x00")
+		},
+	}, true
+}
+
+// Coloring returns the problem of coloring the vertices of g with k colors,
+// numbered 0 to k-1, that minimises the number of edges whose endpoints share
+// a color. Cost 0 is a proper coloring, and Done stops the solvers as soon as
+// one is found. Edge direction is ignored.
+//
+// Solutions map every vertex ID to its color. Neighbor recolors one vertex,
+// picked among those in conflict when there are any (the min-conflicts
+// heuristic), and Crossover takes each vertex's color from a random parent.
+// It returns false when k < 1.
+func Coloring(g *graph.Graph, k int) (Problem[map[string]int], bool) {
+	if g == nil || k < 1 {
+		return Problem[map[string]int]{}, false
+	}
+
+	var ids []string
+	for _, v := range g.GetVertices() {
+		ids = append(ids, v.ID())
+	}
+	sort.Strings(ids)
+
+	type pair struct{ a, b string }
+	seen := make(map[pair]bool)
+	var edges []pair
+	neighbors := make(map[string][]string, len(ids))
+	for _, edge := range g.GetEdges() {
+		a, b := edge.From().ID(), edge.To().ID()
+		if a > b {
+			a, b = b, a
+		}
+		if seen[pair{a, b}] {
+			continue
+		}
+		seen[pair{a, b}] = true
+		edges = append(edges, pair{a, b})
+		neighbors[a] = append(neighbors[a], b)
+		neighbors[b] = append(neighbors[b], a)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].a != edges[j].a {
+			return edges[i].a < edges[j].a
+		}
+		return edges[i].b < edges[j].b
+	})
+
+	copyColors := func(colors map[string]int) map[string]int {
+		next := make(map[string]int, len(colors))
+		for id, c := range colors {
+			next[id] = c
+		}
+		return next
+	}
+
+	return Problem[map[string]int]{
+		Initial: func(r *rand.Rand) map[string]int {
+			colors := make(map[string]int, len(ids))
+			for _, id := range ids {
+				colors[id] = r.Intn(k)
+			}
+			return colors
+		},
+		Cost: func(colors map[string]int) float64 {
+			conflicts := 0
+			for _, e := range edges {
+				if colors[e.a] == colors[e.b] {
+					conflicts++
+				}
+			}
+			return float64(conflicts)
+		},
+		Neighbor: func(colors map[string]int, r *rand.Rand) map[string]int {
+			next := copyColors(colors)
+			if len(ids) == 0 || k == 1 {
+				return next
+			}
+			var conflicted []string
+			for _, id := range ids {
+				for _, other := range neighbors[id] {
+					if colors[id] == colors[other] {
+						conflicted = append(conflicted, id)
+						break
+					}
+				}
+			}
+			if len(conflicted) == 0 {
+				conflicted = ids
+			}
+			id := conflicted[r.Intn(len(conflicted))]
+			next[id] = (colors[id] + 1 + r.Intn(k-1)) % k
+			return next
+		},
+		Crossover: func(a, b map[string]int, r *rand.Rand) map[string]int {
+			child := make(map[string]int, len(ids))
+			for _, id := range ids {
+				if r.Intn(2) == 0 {
+					child[id] = a[id]
+				} else {
+					child[id] = b[id]
+				}
+			}
+			return child
+		},
+		Key: func(colors map[string]int) string {
+			var b strings.Builder
+			for _, id := range ids {
+				b.WriteString(strconv.Itoa(colors[id]))
+				b.WriteByte(',')
+			}
+			return b.String()
+		},
+		Done: func(cost float64) bool {
+			return cost == 0
+		},
+	}, true
+}
@@ -0,0 +1,105 @@
+package metaheuristic
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// GeneticOptions configures Genetic.
+type GeneticOptions struct {
+	Seed int64
+	// Generations is the number of generations bred; values <= 0 use 200.
+	Generations int
+	// PopulationSize is the number of individuals per generation; values
+	// < 2 use 50.
+	PopulationSize int
+	// Elite is the number of best individuals copied unchanged into the next
+	// generation; values <= 0 use 1.
+	Elite int
+	// TournamentSize is the number of individuals compared to select each
+	// parent; values <= 0 use 3.
+	TournamentSize int
+	// CrossoverRate is the probability that a child is bred by crossover
+	// rather than copied from its first parent; values <= 0 use 0.9.
+	CrossoverRate float64
+	// MutationRate is the probability that a child is mutated with
+	// p.Neighbor; values <= 0 use 0.1.
+	MutationRate float64
+}
+
+type individual[S any] struct {
+	solution S
+	cost     float64
+}
+
+// Genetic runs a generational genetic algorithm. Each generation keeps the
+// Elite best individuals and fills the rest of the population with children
+// of parents chosen by tournament selection, bred with p.Crossover and
+// mutated with p.Neighbor. It returns the best individual of the last
+// generation, which elitism makes the best ever seen, or false if p lacks
+// Initial, Cost, Neighbor or Crossover.
+func Genetic[S any](p Problem[S], opts GeneticOptions) (Result[S], bool) {
+	if !p.valid() || p.Crossover == nil {
+		return Result[S]{}, false
+	}
+	generations := defaultInt(opts.Generations, 200)
+	size := opts.PopulationSize
+	if size < 2 {
+		size = 50
+	}
+	elite := min(defaultInt(opts.Elite, 1), size)
+	tournament := defaultInt(opts.TournamentSize, 3)
+	crossoverRate := opts.CrossoverRate
+	if crossoverRate <= 0 {
+		crossoverRate = 0.9
+	}
+	mutationRate := opts.MutationRate
+	if mutationRate <= 0 {
+		mutationRate = 0.1
+	}
+	r := rand.New(rand.NewSource(opts.Seed))
+
+	population := make([]individual[S], size)
+	for i := range population {
+		s := p.Initial(r)
+		population[i] = individual[S]{solution: s, cost: p.Cost(s)}
+	}
+	rank(population)
+
+	selectParent := func() individual[S] {
+		best := population[r.Intn(size)]
+		for i := 1; i < tournament; i++ {
+			if other := population[r.Intn(size)]; other.cost < best.cost {
+				best = other
+			}
+		}
+		return best
+	}
+
+	generation := 0
+	for ; generation < generations && !p.done(population[0].cost); generation++ {
+		next := make([]individual[S], 0, size)
+		next = append(next, population[:elite]...)
+		for len(next) < size {
+			first, second := selectParent(), selectParent()
+			child := first.solution
+			if r.Float64() < crossoverRate {
+				child = p.Crossover(first.solution, second.solution, r)
+			}
+			if r.Float64() < mutationRate {
+				child = p.Neighbor(child, r)
+			}
+			next = append(next, individual[S]{solution: child, cost: p.Cost(child)})
+		}
+		population = next
+		rank(population)
+	}
+
+	return Result[S]{Best: population[0].solution, Cost: population[0].cost, Iterations: generation}, true
+}
+
+// rank sorts a population from best to worst, keeping the order of equally
+// good individuals.
+func rank[S any](population []individual[S]) {
+	sort.SliceStable(population, func(i, j int) bool { return population[i].cost < population[j].cost })
+}
@@ -0,0 +1,210 @@
+package metaheuristic
+
+import (
+	"math"
+	"math/rand"
+)
+
+// HillClimbingOptions configures HillClimbing.
+type HillClimbingOptions struct {
+	Seed int64
+	// MaxIterations bounds the neighbours evaluated by each climb; values
+	// <= 0 use 10000.
+	MaxIterations int
+	// Patience ends a climb after this many neighbours in a row fail to
+	// improve the cost; values <= 0 use 1000.
+	Patience int
+	// Restarts is the number of extra climbs from fresh initial solutions.
+	Restarts int
+}
+
+// HillClimbing runs stochastic hill climbing: it samples a random neighbour
+// and moves to it when it is no worse, so the search can drift across
+// plateaus. A climb ends when its iterations run out or when Patience
+// neighbours in a row bring no improvement; each restart climbs again from a
+// new initial solution. It returns the best solution of all climbs, or false
+// if p lacks Initial, Cost or Neighbor.
+func HillClimbing[S any](p Problem[S], opts HillClimbingOptions) (Result[S], bool) {
+	if !p.valid() {
+		return Result[S]{}, false
+	}
+	maxIterations := defaultInt(opts.MaxIterations, 10000)
+	patience := defaultInt(opts.Patience, 1000)
+	r := rand.New(rand.NewSource(opts.Seed))
+
+	var best tracker[S]
+	iterations := 0
+	for climb := 0; climb <= max(opts.Restarts, 0); climb++ {
+		current := p.Initial(r)
+		cost := p.Cost(current)
+		best.offer(current, cost)
+
+		stale := 0
+		for i := 0; i < maxIterations && stale < patience && !p.done(cost); i++ {
+			candidate := p.Neighbor(current, r)
+			candidateCost := p.Cost(candidate)
+			iterations++
+			if candidateCost < cost {
+				stale = 0
+			} else {
+				stale++
+			}
+			if candidateCost <= cost {
+				current, cost = candidate, candidateCost
+				best.offer(current, cost)
+			}
+		}
+		if p.done(best.result.Cost) {
+			break
+		}
+	}
+
+	best.result.Iterations = iterations
+	return best.result, true
+}
+
+// AnnealingOptions configures SimulatedAnnealing.
+type AnnealingOptions struct {
+	Seed int64
+	// MaxIterations is the number of neighbours evaluated; values <= 0 use
+	// 100000.
+	MaxIterations int
+	// InitialTemperature is the starting temperature. Values <= 0 pick one at
+	// which about 80% of worsening moves from the initial solution are
+	// accepted.
+	InitialTemperature float64
+	// FinalTemperature is reached on the last iteration. Values <= 0 use
+	// InitialTemperature / 1000.
+	FinalTemperature float64
+}
+
+// SimulatedAnnealing runs simulated annealing: it samples a random neighbour
+// and moves to it if it is better, or if it is worse by delta with
+// probability exp(-delta / T). The temperature T cools geometrically from
+// InitialTemperature to FinalTemperature over MaxIterations, so the search
+// explores widely at first and settles into a local optimum at the end.
+// It returns the best solution seen, or false if p lacks Initial, Cost or
+// Neighbor.
+func SimulatedAnnealing[S any](p Problem[S], opts AnnealingOptions) (Result[S], bool) {
+	if !p.valid() {
+		return Result[S]{}, false
+	}
+	maxIterations := defaultInt(opts.MaxIterations, 100000)
+	r := rand.New(rand.NewSource(opts.Seed))
+
+	current := p.Initial(r)
+	cost := p.Cost(current)
+	var best tracker[S]
+	best.offer(current, cost)
+
+	temperature := opts.InitialTemperature
+	if temperature <= 0 {
+		temperature = initialTemperature(p, current, cost, r)
+	}
+	final := opts.FinalTemperature
+	if final <= 0 || final > temperature {
+		final = temperature / 1000
+	}
+	cooling := math.Pow(final/temperature, 1/float64(maxIterations))
+
+	iterations := 0
+	for ; iterations < maxIterations && !p.done(best.result.Cost); iterations++ {
+		candidate := p.Neighbor(current, r)
+		candidateCost := p.Cost(candidate)
+		delta := candidateCost - cost
+		if delta <= 0 || r.Float64() < math.Exp(-delta/temperature) {
+			current, cost = candidate, candidateCost
+			best.offer(current, cost)
+		}
+		temperature *= cooling
+	}
+
+	best.result.Iterations = iterations
+	return best.result, true
+}
+
+// initialTemperature samples neighbours of s and returns the temperature at
+// which their average worsening is accepted with probability 0.8. It falls
+// back to 1 when no sampled neighbour is worse.
+func initialTemperature[S any](p Problem[S], s S, cost float64, r *rand.Rand) float64 {
+	total, worse := 0.0, 0
+	for i := 0; i < 100; i++ {
+		if delta := p.Cost(p.Neighbor(s, r)) - cost; delta > 0 {
+			total += delta
+			worse++
+		}
+	}
+	if worse == 0 {
+		return 1
+	}
+	return -(total / float64(worse)) / math.Log(0.8)
+}
+
+// TabuOptions configures TabuSearch.
+type TabuOptions struct {
+	Seed int64
+	// MaxIterations is the number of moves; values <= 0 use 1000.
+	MaxIterations int
+	// Tenure is how many recent solutions stay tabu; values <= 0 use 10.
+	Tenure int
+	// Candidates is the number of neighbours sampled per move; values <= 0
+	// use 20.
+	Candidates int
+}
+
+// TabuSearch runs tabu search: every move samples Candidates neighbours and
+// moves to the cheapest one that is not tabu, even when it is worse than the
+// current solution, which lets the search climb out of local optima. The
+// last Tenure solutions visited, identified by p.Key, are tabu unless they
+// beat the best solution so far (the aspiration criterion). It returns the
+// best solution seen, or false if p lacks Initial, Cost, Neighbor or Key.
+func TabuSearch[S any](p Problem[S], opts TabuOptions) (Result[S], bool) {
+	if !p.valid() || p.Key == nil {
+		return Result[S]{}, false
+	}
+	maxIterations := defaultInt(opts.MaxIterations, 1000)
+	tenure := defaultInt(opts.Tenure, 10)
+	candidates := defaultInt(opts.Candidates, 20)
+	r := rand.New(rand.NewSource(opts.Seed))
+
+	current := p.Initial(r)
+	var best tracker[S]
+	best.offer(current, p.Cost(current))
+
+	tabu := map[string]bool{p.Key(current): true}
+	recent := []string{p.Key(current)}
+	iterations := 0
+	for move := 0; move < maxIterations && !p.done(best.result.Cost); move++ {
+		var next S
+		nextKey, nextCost, found := "", math.Inf(1), false
+		for i := 0; i < candidates; i++ {
+			candidate := p.Neighbor(current, r)
+			candidateCost := p.Cost(candidate)
+			iterations++
+			key := p.Key(candidate)
+			if tabu[key] && candidateCost >= best.result.Cost {
+				continue
+			}
+			if candidateCost < nextCost {
+				next, nextKey, nextCost, found = candidate, key, candidateCost, true
+			}
+		}
+		if !found {
+			continue
+		}
+
+		current = next
+		best.offer(current, nextCost)
+		if !tabu[nextKey] {
+			tabu[nextKey] = true
+			recent = append(recent, nextKey)
+			if len(recent) > tenure {
+				delete(tabu, recent[0])
+				recent = recent[1:]
+			}
+		}
+	}
+
+	best.result.Iterations = iterations
+	return best.result, true
+}
@@ -0,0 +1,71 @@
+// Package metaheuristic provides approximate solvers for optimisation
+// problems that are too large for exact search: hill climbing, simulated
+// annealing, tabu search and a genetic algorithm.
+//
+// A problem is described by a Problem value whose functions generate,
+// evaluate and perturb candidate solutions, so any objective can be plugged
+// in. TSP and Coloring build ready-made problems over a graph.Graph.
+//
+// Every solver draws its randomness from a source seeded with the Seed in its
+// options, so equal seeds give equal results.
+package metaheuristic
+
+import "math/rand"
+
+// Problem describes a minimisation problem over solutions of type S.
+// Solvers treat solutions as values: Neighbor and Crossover must return new
+// solutions instead of modifying their arguments.
+type Problem[S any] struct {
+	// Initial returns a starting solution, usually a random one. Required.
+	Initial func(r *rand.Rand) S
+	// Cost is the objective to minimise. Required.
+	Cost func(s S) float64
+	// Neighbor returns a random solution close to s. Required.
+	Neighbor func(s S, r *rand.Rand) S
+	// Crossover combines two parents into a child. Required by Genetic only.
+	Crossover func(a, b S, r *rand.Rand) S
+	// Key identifies a solution for the tabu list. Required by TabuSearch
+	// only; equal solutions must have equal keys.
+	Key func(s S) string
+	// Done reports whether a cost is good enough to stop early, for example
+	// no conflicts in a coloring. nil runs every solver to its budget.
+	Done func(cost float64) bool
+}
+
+// Result is the best solution a solver found.
+type Result[S any] struct {
+	Best S
+	Cost float64
+	// Iterations is the number of neighbours evaluated, or of generations
+	// for Genetic.
+	Iterations int
+}
+
+// valid reports whether the functions every solver needs are set.
+func (p Problem[S]) valid() bool {
+	return p.Initial != nil && p.Cost != nil && p.Neighbor != nil
+}
+
+func (p Problem[S]) done(cost float64) bool {
+	return p.Done != nil && p.Done(cost)
+}
+
+// tracker keeps the best solution seen so far.
+type tracker[S any] struct {
+	result Result[S]
+	seen   bool
+}
+
+func (t *tracker[S]) offer(s S, cost float64) {
+	if !t.seen || cost < t.result.Cost {
+		t.result.Best, t.result.Cost = s, cost
+		t.seen = true
+	}
+}
+
+func defaultInt(value, fallback int) int {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
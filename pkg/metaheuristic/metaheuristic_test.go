@@ -0,0 +1,175 @@
+package metaheuristic
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/JeanGrijp/go-datastructures/pkg/graph"
+)
+
+// parabola minimises (x - 42)^2 over the integers, moving x by one step.
+func parabola() Problem[int] {
+	return Problem[int]{
+		Initial:  func(r *rand.Rand) int { return r.Intn(2001) - 1000 },
+		Cost:     func(x int) float64 { return float64((x - 42) * (x - 42)) },
+		Neighbor: func(x int, r *rand.Rand) int { return x + 2*r.Intn(2) - 1 },
+		Crossover: func(a, b int, r *rand.Rand) int {
+			return (a + b) / 2
+		},
+		Key: strconv.Itoa,
+	}
+}
+
+// solvers returns a closure per solver that runs it on p with the given seed.
+func solvers[S any](p Problem[S], seed int64) map[string]func() (Result[S], bool) {
+	return map[string]func() (Result[S], bool){
+		"HillClimbing": func() (Result[S], bool) {
+			return HillClimbing(p, HillClimbingOptions{Seed: seed, Restarts: 3})
+		},
+		"SimulatedAnnealing": func() (Result[S], bool) {
+			return SimulatedAnnealing(p, AnnealingOptions{Seed: seed, MaxIterations: 20000})
+		},
+		"TabuSearch": func() (Result[S], bool) {
+			return TabuSearch(p, TabuOptions{Seed: seed, MaxIterations: 2000})
+		},
+		"Genetic": func() (Result[S], bool) {
+			return Genetic(p, GeneticOptions{Seed: seed, Generations: 300, MutationRate: 0.5})
+		},
+	}
+}
+
+func TestSolversFindMinimum(t *testing.T) {
+	for name, solve := range solvers(parabola(), 1) {
+		result, ok := solve()
+		if !ok {
+			t.Fatalf("%s: expected success", name)
+		}
+		if result.Best != 42 || result.Cost != 0 {
+			t.Errorf("%s: expected 42 at cost 0, got %d at cost %v", name, result.Best, result.Cost)
+		}
+		if result.Iterations == 0 {
+			t.Errorf("%s: expected iterations to be counted", name)
+		}
+	}
+}
+
+func TestSolversAreReproducible(t *testing.T) {
+	g := graph.BuildRomaniaGraph()
+	p, ok := TSP(g, []string{"Arad", "Bucharest", "Craiova", "Fagaras", "Iasi", "Lugoj", "Neamt", "Oradea", "Sibiu", "Zerind"})
+	if !ok {
+		t.Fatal("expected TSP to succeed")
+	}
+	first, second := solvers(p, 7), solvers(p, 7)
+	for name := range first {
+		a, _ := first[name]()
+		b, _ := second[name]()
+		if !reflect.DeepEqual(a, b) {
+			t.Errorf("%s: equal seeds gave %v and %v", name, a, b)
+		}
+	}
+}
+
+func TestSolversRejectIncompleteProblems(t *testing.T) {
+	p := parabola()
+	p.Neighbor = nil
+	for name, solve := range solvers(p, 1) {
+		if _, ok := solve(); ok {
+			t.Errorf("%s: expected a problem without Neighbor to be rejected", name)
+		}
+	}
+
+	p = parabola()
+	p.Key = nil
+	if _, ok := TabuSearch(p, TabuOptions{}); ok {
+		t.Error("expected TabuSearch to require Key")
+	}
+	p = parabola()
+	p.Crossover = nil
+	if _, ok := Genetic(p, GeneticOptions{}); ok {
+		t.Error("expected Genetic to require Crossover")
+	}
+}
+
+func TestDoneStopsEarly(t *testing.T) {
+	p := parabola()
+	p.Done = func(cost float64) bool { return cost <= 100 }
+	result, _ := HillClimbing(p, HillClimbingOptions{MaxIterations: 1 << 20, Patience: 1 << 20})
+	if result.Cost > 100 || result.Iterations >= 1<<20 {
+		t.Fatalf("expected the climb to stop once the cost is <= 100, got %v after %d iterations", result.Cost, result.Iterations)
+	}
+}
+
+func TestTSPAdapter(t *testing.T) {
+	g := graph.BuildRomaniaGraph()
+	cities := []string{"Arad", "Bucharest", "Craiova", "Fagaras", "Iasi", "Lugoj", "Neamt", "Oradea", "Sibiu", "Zerind"}
+	_, optimal, _ := g.TSPHeldKarp(cities)
+
+	p, ok := TSP(g, cities)
+	if !ok {
+		t.Fatal("expected TSP to succeed")
+	}
+	r := rand.New(rand.NewSource(1))
+	a, b := p.Initial(r), p.Initial(r)
+	for _, order := range [][]string{a, b, p.Neighbor(a, r), p.Crossover(a, b, r)} {
+		if order[0] != "Arad" || len(order) != len(cities) {
+			t.Fatalf("expected a permutation starting at Arad, got %v", order)
+		}
+		seen := make(map[string]bool)
+		for _, city := range order {
+			seen[city] = true
+		}
+		if len(seen) != len(cities) {
+			t.Fatalf("expected every city exactly once, got %v", order)
+		}
+	}
+
+	result, _ := SimulatedAnnealing(p, AnnealingOptions{Seed: 3})
+	if result.Cost != float64(optimal) {
+		t.Fatalf("expected annealing to reach the optimum %d, got %v", optimal, result.Cost)
+	}
+	tour, cost, ok := g.ExpandTour(append(result.Best, result.Best[0]))
+	if !ok || float64(cost) != result.Cost || tour[0] != "Arad" {
+		t.Fatalf("expected the closed tour to expand at cost %v, got %d", result.Cost, cost)
+	}
+
+	if _, ok := TSP(g, []string{"Arad", "Atlantis"}); ok {
+		t.Fatal("expected unknown cities to be rejected")
+	}
+}
+
+func TestColoringAdapter(t *testing.T) {
+	g := graph.BuildRomaniaGraph()
+	p, ok := Coloring(g, 3)
+	if !ok {
+		t.Fatal("expected Coloring to succeed")
+	}
+	for name, solve := range solvers(p, 5) {
+		result, _ := solve()
+		if result.Cost != 0 {
+			t.Errorf("%s: expected a proper 3-coloring, got %v conflicts", name, result.Cost)
+			continue
+		}
+		for _, edge := range g.GetEdges() {
+			if result.Best[edge.From().ID()] == result.Best[edge.To().ID()] {
+				t.Errorf("%s: %s and %s share a color", name, edge.From().ID(), edge.To().ID())
+			}
+		}
+	}
+
+	// A triangle cannot be 2-colored: the best possible is one conflict.
+	triangle := graph.NewGraph(false)
+	triangle.AddEdge("a", "b", 1)
+	triangle.AddEdge("b", "c", 1)
+	triangle.AddEdge("c", "a", 1)
+	p, _ = Coloring(triangle, 2)
+	result, _ := TabuSearch(p, TabuOptions{})
+	if result.Cost != 1 {
+		t.Fatalf("expected one conflict, got %v", result.Cost)
+	}
+
+	if _, ok := Coloring(g, 0); ok {
+		t.Fatal("expected k < 1 to be rejected")
+	}
+}
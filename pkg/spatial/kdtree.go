@@ -0,0 +1,149 @@
+package spatial
+
+import "sort"
+
+// KDTree is a two-dimensional k-d tree. Each level splits the points by x or
+// y in turn. NewKDTree builds a balanced tree; points added later with Insert
+// go to the leaves, so rebuild the tree after many insertions to keep queries
+// fast.
+type KDTree[T any] struct {
+	root *kdNode[T]
+	size int
+}
+
+type kdNode[T any] struct {
+	entry       Entry[T]
+	seq         int
+	left, right *kdNode[T] // left holds smaller coordinates on this level's axis
+}
+
+// NewKDTree builds a balanced k-d tree from entries in O(n log^2 n) by
+// splitting at the median of each level. Entries with NaN coordinates are
+// skipped.
+func NewKDTree[T any](entries []Entry[T]) *KDTree[T] {
+	nodes := make([]*kdNode[T], 0, len(entries))
+	for _, entry := range entries {
+		if validPoint(entry.Point) {
+			nodes = append(nodes, &kdNode[T]{entry: entry, seq: len(nodes)})
+		}
+	}
+	return &KDTree[T]{root: buildKD(nodes, 0), size: len(nodes)}
+}
+
+func buildKD[T any](nodes []*kdNode[T], depth int) *kdNode[T] {
+	if len(nodes) == 0 {
+		return nil
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		a, b := coordinate(nodes[i].entry.Point, depth), coordinate(nodes[j].entry.Point, depth)
+		if a != b {
+			return a < b
+		}
+		return nodes[i].seq < nodes[j].seq
+	})
+	median := len(nodes) / 2
+	// Equal coordinates must all sit on the right, where Insert sends them.
+	for median > 0 && coordinate(nodes[median-1].entry.Point, depth) == coordinate(nodes[median].entry.Point, depth) {
+		median--
+	}
+	node := nodes[median]
+	node.left = buildKD(nodes[:median], depth+1)
+	node.right = buildKD(nodes[median+1:], depth+1)
+	return node
+}
+
+// coordinate returns the coordinate of p compared at the given depth.
+func coordinate(p Point, depth int) float64 {
+	if depth%2 == 0 {
+		return p.X
+	}
+	return p.Y
+}
+
+// Insert adds a point with its value. It returns false for points with NaN
+// coordinates.
+func (t *KDTree[T]) Insert(p Point, value T) bool {
+	if !validPoint(p) {
+		return false
+	}
+	node := &kdNode[T]{entry: Entry[T]{Point: p, Value: value}, seq: t.size}
+	t.size++
+
+	link := &t.root
+	for depth := 0; *link != nil; depth++ {
+		if coordinate(p, depth) < coordinate((*link).entry.Point, depth) {
+			link = &(*link).left
+		} else {
+			link = &(*link).right
+		}
+	}
+	*link = node
+	return true
+}
+
+// Len returns the number of points in the tree.
+func (t *KDTree[T]) Len() int {
+	return t.size
+}
+
+// Nearest returns the point closest to p, or false if the tree is empty.
+func (t *KDTree[T]) Nearest(p Point) (Entry[T], bool) {
+	nearest := t.KNearest(p, 1)
+	if len(nearest) == 0 {
+		return Entry[T]{}, false
+	}
+	return nearest[0], true
+}
+
+// KNearest returns the k points closest to p, nearest first. It returns
+// fewer when the tree holds fewer than k points and none when k <= 0.
+func (t *KDTree[T]) KNearest(p Point, k int) []Entry[T] {
+	if k <= 0 || t.size == 0 || !validPoint(p) {
+		return []Entry[T]{}
+	}
+	set := newNearestSet[Entry[T]](min(k, t.size))
+	t.nearest(t.root, 0, p, set)
+	return set.result()
+}
+
+func (t *KDTree[T]) nearest(node *kdNode[T], depth int, p Point, set *nearestSet[Entry[T]]) {
+	if node == nil {
+		return
+	}
+	set.offer(candidate[Entry[T]]{item: node.entry, dist: p.distance2(node.entry.Point), seq: node.seq})
+
+	diff := coordinate(p, depth) - coordinate(node.entry.Point, depth)
+	near, far := node.left, node.right
+	if diff >= 0 {
+		near, far = far, near
+	}
+	t.nearest(near, depth+1, p, set)
+	if diff*diff <= set.bound() {
+		t.nearest(far, depth+1, p, set)
+	}
+}
+
+// Range returns the points inside r, in insertion order.
+func (t *KDTree[T]) Range(r Rect) []Entry[T] {
+	var matches []candidate[Entry[T]]
+	var visit func(node *kdNode[T], depth int)
+	visit = func(node *kdNode[T], depth int) {
+		if node == nil {
+			return
+		}
+		if r.Contains(node.entry.Point) {
+			matches = append(matches, candidate[Entry[T]]{item: node.entry, seq: node.seq})
+		}
+		c := coordinate(node.entry.Point, depth)
+		if coordinate(r.Min, depth) < c {
+			visit(node.left, depth+1)
+		}
+		if coordinate(r.Max, depth) >= c {
+			visit(node.right, depth+1)
+		}
+	}
+	if r.Valid() {
+		visit(t.root, 0)
+	}
+	return inInsertionOrder(matches)
+}
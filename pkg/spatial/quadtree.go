@@ -0,0 +1,173 @@
+package spatial
+
+import "sort"
+
+// maxQuadDepth stops splitting when many points share a position, which no
+// number of splits could separate.
+const maxQuadDepth = 32
+
+// QuadTree is a point quadtree over a fixed bounding rectangle. Each node
+// holds up to capacity points; a full node splits into four equal quadrants.
+// Unlike a k-d tree it stays balanced for its region under any insertion
+// order, which suits points that arrive one at a time.
+type QuadTree[T any] struct {
+	root     *quadNode[T]
+	capacity int
+	size     int
+}
+
+type quadNode[T any] struct {
+	bounds   Rect
+	items    []candidate[Entry[T]] // points of a leaf; dist is unused
+	children []*quadNode[T]        // nil for a leaf, else 4 quadrants
+	depth    int
+}
+
+// NewQuadTree returns an empty quadtree covering bounds, whose leaves hold up
+// to capacity points before splitting. capacity <= 0 uses 8. It returns
+// (nil, false) if bounds is not a valid rectangle.
+func NewQuadTree[T any](bounds Rect, capacity int) (*QuadTree[T], bool) {
+	if !bounds.Valid() {
+		return nil, false
+	}
+	if capacity <= 0 {
+		capacity = 8
+	}
+	return &QuadTree[T]{root: &quadNode[T]{bounds: bounds}, capacity: capacity}, true
+}
+
+// Bounds returns the region covered by the tree.
+func (q *QuadTree[T]) Bounds() Rect {
+	return q.root.bounds
+}
+
+// Len returns the number of points in the tree.
+func (q *QuadTree[T]) Len() int {
+	return q.size
+}
+
+// Insert adds a point with its value. It returns false for points outside
+// the tree's bounds.
+func (q *QuadTree[T]) Insert(p Point, value T) bool {
+	if !q.root.bounds.Contains(p) {
+		return false
+	}
+	item := candidate[Entry[T]]{item: Entry[T]{Point: p, Value: value}, seq: q.size}
+	q.size++
+
+	node := q.root
+	for node.children != nil {
+		node = node.children[node.quadrant(p)]
+	}
+	node.items = append(node.items, item)
+	if len(node.items) > q.capacity && node.depth < maxQuadDepth {
+		node.split(q.capacity)
+	}
+	return true
+}
+
+// quadrant returns the index of the child whose region holds p. Points on a
+// dividing line go to the upper or right quadrant.
+func (n *quadNode[T]) quadrant(p Point) int {
+	mid := n.mid()
+	i := 0
+	if p.X >= mid.X {
+		i |= 1
+	}
+	if p.Y >= mid.Y {
+		i |= 2
+	}
+	return i
+}
+
+func (n *quadNode[T]) mid() Point {
+	return Point{X: (n.bounds.Min.X + n.bounds.Max.X) / 2, Y: (n.bounds.Min.Y + n.bounds.Max.Y) / 2}
+}
+
+// split turns a leaf into an inner node, pushing its points down. Quadrants
+// that still hold more than capacity points split again.
+func (n *quadNode[T]) split(capacity int) {
+	mid := n.mid()
+	lo, hi := n.bounds.Min, n.bounds.Max
+	n.children = []*quadNode[T]{
+		{bounds: Rect{Min: lo, Max: mid}, depth: n.depth + 1},
+		{bounds: Rect{Min: Point{X: mid.X, Y: lo.Y}, Max: Point{X: hi.X, Y: mid.Y}}, depth: n.depth + 1},
+		{bounds: Rect{Min: Point{X: lo.X, Y: mid.Y}, Max: Point{X: mid.X, Y: hi.Y}}, depth: n.depth + 1},
+		{bounds: Rect{Min: mid, Max: hi}, depth: n.depth + 1},
+	}
+	for _, item := range n.items {
+		child := n.children[n.quadrant(item.item.Point)]
+		child.items = append(child.items, item)
+	}
+	n.items = nil
+	for _, child := range n.children {
+		if len(child.items) > capacity && child.depth < maxQuadDepth {
+			child.split(capacity)
+		}
+	}
+}
+
+// Nearest returns the point closest to p, or false if the tree is empty.
+func (q *QuadTree[T]) Nearest(p Point) (Entry[T], bool) {
+	nearest := q.KNearest(p, 1)
+	if len(nearest) == 0 {
+		return Entry[T]{}, false
+	}
+	return nearest[0], true
+}
+
+// KNearest returns the k points closest to p, nearest first. It returns
+// fewer when the tree holds fewer than k points and none when k <= 0.
+// Quadrants are searched nearest first and skipped once they are farther
+// than the k-th best point found so far.
+func (q *QuadTree[T]) KNearest(p Point, k int) []Entry[T] {
+	if k <= 0 || q.size == 0 || !validPoint(p) {
+		return []Entry[T]{}
+	}
+	set := newNearestSet[Entry[T]](min(k, q.size))
+	var visit func(n *quadNode[T])
+	visit = func(n *quadNode[T]) {
+		if n.bounds.distance2(p) > set.bound() {
+			return
+		}
+		for _, item := range n.items {
+			item.dist = p.distance2(item.item.Point)
+			set.offer(item)
+		}
+		if n.children == nil {
+			return
+		}
+		order := []*quadNode[T]{n.children[0], n.children[1], n.children[2], n.children[3]}
+		sort.SliceStable(order, func(i, j int) bool {
+			return order[i].bounds.distance2(p) < order[j].bounds.distance2(p)
+		})
+		for _, child := range order {
+			visit(child)
+		}
+	}
+	visit(q.root)
+	return set.result()
+}
+
+// Range returns the points inside r, in insertion order.
+func (q *QuadTree[T]) Range(r Rect) []Entry[T] {
+	var matches []candidate[Entry[T]]
+	var visit func(n *quadNode[T])
+	visit = func(n *quadNode[T]) {
+		if !n.bounds.Intersects(r) {
+			return
+		}
+		for _, item := range n.items {
+			if r.Contains(item.item.Point) {
+				matches = append(matches, item)
+			}
+		}
+		for _, child := range n.children {
+			visit(child)
+		}
+	}
+	if r.Valid() {
+		visit(q.root)
+	}
+	return inInsertionOrder(matches)
+}
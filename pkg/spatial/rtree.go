@@ -0,0 +1,260 @@
+package spatial
+
+import (
+	"math"
+	"sort"
+)
+
+// Box is a rectangle stored in an R-tree together with its value.
+type Box[T any] struct {
+	Rect  Rect
+	Value T
+}
+
+// RTree indexes rectangles, such as the bounding boxes of roads or regions,
+// with Guttman's R-tree: every node holds up to maxEntries rectangles and
+// covers them with its bounding box, and overfull nodes split with the
+// quadratic algorithm. Points can be stored as rectangles with Min equal to
+// Max.
+type RTree[T any] struct {
+	root       *rNode[T]
+	maxEntries int
+	minEntries int
+	size       int
+}
+
+type rNode[T any] struct {
+	leaf    bool
+	entries []rEntry[T]
+}
+
+// rEntry is a child of an inner node or a stored box of a leaf.
+type rEntry[T any] struct {
+	rect  Rect
+	child *rNode[T]
+	box   candidate[Box[T]]
+}
+
+// NewRTree returns an empty R-tree whose nodes hold up to maxEntries
+// rectangles. Values below 4 use 8. Nodes other than the root hold at least
+// maxEntries/2.
+func NewRTree[T any](maxEntries int) *RTree[T] {
+	if maxEntries < 4 {
+		maxEntries = 8
+	}
+	return &RTree[T]{
+		root:       &rNode[T]{leaf: true},
+		maxEntries: maxEntries,
+		minEntries: maxEntries / 2,
+	}
+}
+
+// Len returns the number of rectangles in the tree.
+func (t *RTree[T]) Len() int {
+	return t.size
+}
+
+// Insert adds a rectangle with its value. It returns false if r is not a
+// valid rectangle.
+func (t *RTree[T]) Insert(r Rect, value T) bool {
+	if !r.Valid() {
+		return false
+	}
+	entry := rEntry[T]{rect: r, box: candidate[Box[T]]{item: Box[T]{Rect: r, Value: value}, seq: t.size}}
+	t.size++
+
+	if sibling := t.insert(t.root, entry); sibling != nil {
+		old := t.root
+		t.root = &rNode[T]{entries: []rEntry[T]{
+			{rect: old.bounds(), child: old},
+			{rect: sibling.bounds(), child: sibling},
+		}}
+	}
+	return true
+}
+
+// insert adds entry below n and returns the new sibling of n if n split.
+func (t *RTree[T]) insert(n *rNode[T], entry rEntry[T]) *rNode[T] {
+	if n.leaf {
+		n.entries = append(n.entries, entry)
+	} else {
+		i := chooseSubtree(n, entry.rect)
+		child := n.entries[i].child
+		sibling := t.insert(child, entry)
+		n.entries[i].rect = child.bounds()
+		if sibling != nil {
+			n.entries = append(n.entries, rEntry[T]{rect: sibling.bounds(), child: sibling})
+		}
+	}
+
+	if len(n.entries) > t.maxEntries {
+		return t.split(n)
+	}
+	return nil
+}
+
+// chooseSubtree picks the child whose rectangle grows least to cover r,
+// preferring the smaller rectangle on ties.
+func chooseSubtree[T any](n *rNode[T], r Rect) int {
+	best, bestGrowth, bestArea := 0, math.Inf(1), math.Inf(1)
+	for i, entry := range n.entries {
+		area := entry.rect.area()
+		growth := entry.rect.union(r).area() - area
+		if growth < bestGrowth || (growth == bestGrowth && area < bestArea) {
+			best, bestGrowth, bestArea = i, growth, area
+		}
+	}
+	return best
+}
+
+// split divides the entries of an overfull node with Guttman's quadratic
+// split. n keeps the first group and the returned node gets the second.
+func (t *RTree[T]) split(n *rNode[T]) *rNode[T] {
+	entries := n.entries
+
+	// Seed the groups with the pair that would waste the most area together.
+	seedA, seedB, worst := 0, 1, math.Inf(-1)
+	for i := range entries {
+		for j := i + 1; j < len(entries); j++ {
+			waste := entries[i].rect.union(entries[j].rect).area() - entries[i].rect.area() - entries[j].rect.area()
+			if waste > worst {
+				seedA, seedB, worst = i, j, waste
+			}
+		}
+	}
+
+	groups := [2][]rEntry[T]{{entries[seedA]}, {entries[seedB]}}
+	covers := [2]Rect{entries[seedA].rect, entries[seedB].rect}
+	remaining := make([]rEntry[T], 0, len(entries)-2)
+	for i, entry := range entries {
+		if i != seedA && i != seedB {
+			remaining = append(remaining, entry)
+		}
+	}
+
+	for len(remaining) > 0 {
+		// A group that needs every remaining entry to reach the minimum gets them.
+		for g := range groups {
+			if len(groups[g])+len(remaining) <= t.minEntries {
+				for _, entry := range remaining {
+					groups[g] = append(groups[g], entry)
+					covers[g] = covers[g].union(entry.rect)
+				}
+				remaining = nil
+			}
+		}
+		if len(remaining) == 0 {
+			break
+		}
+
+		// Otherwise place the entry with the strongest preference for a group.
+		pick, preference := 0, math.Inf(-1)
+		for i, entry := range remaining {
+			growA := covers[0].union(entry.rect).area() - covers[0].area()
+			growB := covers[1].union(entry.rect).area() - covers[1].area()
+			if d := math.Abs(growA - growB); d > preference {
+				pick, preference = i, d
+			}
+		}
+		entry := remaining[pick]
+		remaining = append(remaining[:pick], remaining[pick+1:]...)
+
+		growA := covers[0].union(entry.rect).area() - covers[0].area()
+		growB := covers[1].union(entry.rect).area() - covers[1].area()
+		g := 0
+		switch {
+		case growB < growA:
+			g = 1
+		case growA == growB && covers[1].area() < covers[0].area():
+			g = 1
+		case growA == growB && covers[1].area() == covers[0].area() && len(groups[1]) < len(groups[0]):
+			g = 1
+		}
+		groups[g] = append(groups[g], entry)
+		covers[g] = covers[g].union(entry.rect)
+	}
+
+	n.entries = groups[0]
+	return &rNode[T]{leaf: n.leaf, entries: groups[1]}
+}
+
+// bounds returns the smallest rectangle covering every entry of n.
+func (n *rNode[T]) bounds() Rect {
+	cover := n.entries[0].rect
+	for _, entry := range n.entries[1:] {
+		cover = cover.union(entry.rect)
+	}
+	return cover
+}
+
+// Search returns the boxes that intersect r, in insertion order.
+func (t *RTree[T]) Search(r Rect) []Box[T] {
+	var matches []candidate[Box[T]]
+	var visit func(n *rNode[T])
+	visit = func(n *rNode[T]) {
+		for _, entry := range n.entries {
+			if !entry.rect.Intersects(r) {
+				continue
+			}
+			if n.leaf {
+				matches = append(matches, entry.box)
+			} else {
+				visit(entry.child)
+			}
+		}
+	}
+	if r.Valid() {
+		visit(t.root)
+	}
+	return inInsertionOrder(matches)
+}
+
+// SearchPoint returns the boxes that contain p, in insertion order.
+func (t *RTree[T]) SearchPoint(p Point) []Box[T] {
+	return t.Search(Rect{Min: p, Max: p})
+}
+
+// Nearest returns the box closest to p, measured to the nearest point of
+// each box, or false if the tree is empty. Boxes containing p are at
+// distance 0.
+func (t *RTree[T]) Nearest(p Point) (Box[T], bool) {
+	nearest := t.KNearest(p, 1)
+	if len(nearest) == 0 {
+		return Box[T]{}, false
+	}
+	return nearest[0], true
+}
+
+// KNearest returns the k boxes closest to p, nearest first. It returns fewer
+// when the tree holds fewer than k boxes and none when k <= 0.
+func (t *RTree[T]) KNearest(p Point, k int) []Box[T] {
+	if k <= 0 || t.size == 0 || !validPoint(p) {
+		return []Box[T]{}
+	}
+	set := newNearestSet[Box[T]](min(k, t.size))
+	var visit func(n *rNode[T])
+	visit = func(n *rNode[T]) {
+		if n.leaf {
+			for _, entry := range n.entries {
+				box := entry.box
+				box.dist = entry.rect.distance2(p)
+				set.offer(box)
+			}
+			return
+		}
+		// Visit children nearest first so that far ones can be skipped.
+		children := make([]candidate[*rNode[T]], len(n.entries))
+		for i, entry := range n.entries {
+			children[i] = candidate[*rNode[T]]{item: entry.child, dist: entry.rect.distance2(p), seq: i}
+		}
+		sort.Slice(children, func(i, j int) bool { return worse(children[j], children[i]) })
+		for _, child := range children {
+			if child.dist > set.bound() {
+				break
+			}
+			visit(child.item)
+		}
+	}
+	visit(t.root)
+	return set.result()
+}
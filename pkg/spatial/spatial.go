@@ -0,0 +1,180 @@
+// Package spatial provides in-memory spatial indexes over the plane: a k-d
+// tree and a point quadtree for points, and an R-tree for axis-aligned boxes.
+// Every index answers nearest-neighbour, k-nearest and range queries under
+// Euclidean distance, and FromGraph indexes the vertices of a graph.Graph by
+// their coordinates, for example to snap a clicked map position to the
+// nearest vertex before running A*.
+//
+// Results are deterministic: equally distant items are returned in insertion
+// order, and range queries list items in insertion order.
+package spatial
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// Point is a position in the plane.
+type Point struct {
+	X, Y float64
+}
+
+// Distance returns the Euclidean distance between p and q.
+func (p Point) Distance(q Point) float64 {
+	return math.Sqrt(p.distance2(q))
+}
+
+func (p Point) distance2(q Point) float64 {
+	dx, dy := p.X-q.X, p.Y-q.Y
+	return dx*dx + dy*dy
+}
+
+// Rect is an axis-aligned rectangle with inclusive bounds. It is valid when
+// Min.X <= Max.X and Min.Y <= Max.Y; a rectangle with Min equal to Max is a
+// single point.
+type Rect struct {
+	Min, Max Point
+}
+
+// NewRect returns the rectangle with corners (x1, y1) and (x2, y2), in any
+// order.
+func NewRect(x1, y1, x2, y2 float64) Rect {
+	return Rect{
+		Min: Point{X: math.Min(x1, x2), Y: math.Min(y1, y2)},
+		Max: Point{X: math.Max(x1, x2), Y: math.Max(y1, y2)},
+	}
+}
+
+// Valid reports whether r has Min <= Max on both axes. Rectangles with NaN
+// coordinates are not valid.
+func (r Rect) Valid() bool {
+	return r.Min.X <= r.Max.X && r.Min.Y <= r.Max.Y
+}
+
+// Contains reports whether p lies inside r or on its boundary.
+func (r Rect) Contains(p Point) bool {
+	return p.X >= r.Min.X && p.X <= r.Max.X && p.Y >= r.Min.Y && p.Y <= r.Max.Y
+}
+
+// Intersects reports whether r and s share at least one point.
+func (r Rect) Intersects(s Rect) bool {
+	return r.Min.X <= s.Max.X && s.Min.X <= r.Max.X && r.Min.Y <= s.Max.Y && s.Min.Y <= r.Max.Y
+}
+
+// Distance returns the Euclidean distance from p to the closest point of r,
+// 0 when r contains p.
+func (r Rect) Distance(p Point) float64 {
+	return math.Sqrt(r.distance2(p))
+}
+
+func (r Rect) distance2(p Point) float64 {
+	dx := math.Max(0, math.Max(r.Min.X-p.X, p.X-r.Max.X))
+	dy := math.Max(0, math.Max(r.Min.Y-p.Y, p.Y-r.Max.Y))
+	return dx*dx + dy*dy
+}
+
+func (r Rect) area() float64 {
+	return (r.Max.X - r.Min.X) * (r.Max.Y - r.Min.Y)
+}
+
+// union returns the smallest rectangle containing r and s.
+func (r Rect) union(s Rect) Rect {
+	return Rect{
+		Min: Point{X: math.Min(r.Min.X, s.Min.X), Y: math.Min(r.Min.Y, s.Min.Y)},
+		Max: Point{X: math.Max(r.Max.X, s.Max.X), Y: math.Max(r.Max.Y, s.Max.Y)},
+	}
+}
+
+// Entry is a point stored in a point index together with its value.
+type Entry[T any] struct {
+	Point Point
+	Value T
+}
+
+// validPoint rejects points that no query could ever match.
+func validPoint(p Point) bool {
+	return !math.IsNaN(p.X) && !math.IsNaN(p.Y)
+}
+
+// candidate is an item considered by a nearest-neighbour search. seq is the
+// item's insertion number, which breaks ties between equal distances.
+type candidate[E any] struct {
+	item E
+	dist float64 // squared distance to the query
+	seq  int
+}
+
+// nearestSet keeps the k best candidates seen so far in a max-heap, worst on
+// top, so a search can prune everything farther than the current worst.
+type nearestSet[E any] struct {
+	k     int
+	items []candidate[E]
+}
+
+func newNearestSet[E any](k int) *nearestSet[E] {
+	return &nearestSet[E]{k: k, items: make([]candidate[E], 0, k)}
+}
+
+func (s *nearestSet[E]) Len() int { return len(s.items) }
+
+func (s *nearestSet[E]) Less(i, j int) bool { return worse(s.items[i], s.items[j]) }
+
+func (s *nearestSet[E]) Swap(i, j int) { s.items[i], s.items[j] = s.items[j], s.items[i] }
+
+func (s *nearestSet[E]) Push(x any) { s.items = append(s.items, x.(candidate[E])) }
+
+func (s *nearestSet[E]) Pop() any {
+	last := s.items[len(s.items)-1]
+	s.items = s.items[:len(s.items)-1]
+	return last
+}
+
+func worse[E any](a, b candidate[E]) bool {
+	if a.dist != b.dist {
+		return a.dist > b.dist
+	}
+	return a.seq > b.seq
+}
+
+// offer adds c if it beats the current worst candidate or the set is not
+// full yet.
+func (s *nearestSet[E]) offer(c candidate[E]) {
+	if len(s.items) < s.k {
+		heap.Push(s, c)
+		return
+	}
+	if worse(s.items[0], c) {
+		s.items[0] = c
+		heap.Fix(s, 0)
+	}
+}
+
+// bound returns the squared distance beyond which nothing can enter the set.
+// Regions at exactly this distance may still hold an earlier-inserted tie.
+func (s *nearestSet[E]) bound() float64 {
+	if len(s.items) < s.k {
+		return math.Inf(1)
+	}
+	return s.items[0].dist
+}
+
+// result returns the candidates from nearest to farthest.
+func (s *nearestSet[E]) result() []E {
+	sort.Slice(s.items, func(i, j int) bool { return worse(s.items[j], s.items[i]) })
+	result := make([]E, len(s.items))
+	for i, c := range s.items {
+		result[i] = c.item
+	}
+	return result
+}
+
+// inInsertionOrder sorts the matches of a range query by insertion number.
+func inInsertionOrder[E any](matches []candidate[E]) []E {
+	sort.Slice(matches, func(i, j int) bool { return matches[i].seq < matches[j].seq })
+	result := make([]E, len(matches))
+	for i, c := range matches {
+		result[i] = c.item
+	}
+	return result
+}
@@ -0,0 +1,214 @@
+package spatial
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/JeanGrijp/go-datastructures/pkg/graph"
+)
+
+// pointIndex is the query interface shared by KDTree and QuadTree.
+type pointIndex interface {
+	Len() int
+	Nearest(p Point) (Entry[int], bool)
+	KNearest(p Point, k int) []Entry[int]
+	Range(r Rect) []Entry[int]
+}
+
+// randomEntries returns n points on a coarse grid, so that duplicates and
+// equal distances are common. Values are the insertion order.
+func randomEntries(r *rand.Rand, n int) []Entry[int] {
+	entries := make([]Entry[int], n)
+	for i := range entries {
+		entries[i] = Entry[int]{Point: Point{X: float64(r.Intn(50)), Y: float64(r.Intn(50))}, Value: i}
+	}
+	return entries
+}
+
+// bruteKNearest sorts entries by distance to p, ties by insertion order.
+func bruteKNearest(entries []Entry[int], p Point, k int) []Entry[int] {
+	sorted := append([]Entry[int](nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return p.distance2(sorted[i].Point) < p.distance2(sorted[j].Point)
+	})
+	return sorted[:min(k, len(sorted))]
+}
+
+func bruteRange(entries []Entry[int], r Rect) []Entry[int] {
+	matches := []Entry[int]{}
+	for _, entry := range entries {
+		if r.Contains(entry.Point) {
+			matches = append(matches, entry)
+		}
+	}
+	return matches
+}
+
+func checkPointIndex(t *testing.T, name string, index pointIndex, entries []Entry[int], r *rand.Rand) {
+	t.Helper()
+	if index.Len() != len(entries) {
+		t.Fatalf("%s: expected %d points, got %d", name, len(entries), index.Len())
+	}
+	for query := 0; query < 100; query++ {
+		p := Point{X: r.Float64()*60 - 5, Y: r.Float64()*60 - 5}
+		k := 1 + r.Intn(10)
+		if got, expected := index.KNearest(p, k), bruteKNearest(entries, p, k); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("%s: expected %d nearest to %v to be %v, got %v", name, k, p, expected, got)
+		}
+		if got, ok := index.Nearest(p); !ok || got != bruteKNearest(entries, p, 1)[0] {
+			t.Fatalf("%s: expected nearest to %v to match a linear scan, got %v", name, p, got)
+		}
+
+		box := NewRect(r.Float64()*50, r.Float64()*50, r.Float64()*50, r.Float64()*50)
+		if got, expected := index.Range(box), bruteRange(entries, box); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("%s: expected %d points in %v, got %d", name, len(expected), box, len(got))
+		}
+	}
+}
+
+func TestKDTreeMatchesLinearScan(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	entries := randomEntries(r, 500)
+	checkPointIndex(t, "balanced", NewKDTree(entries), entries, r)
+
+	inserted := NewKDTree[int](nil)
+	for _, entry := range entries {
+		inserted.Insert(entry.Point, entry.Value)
+	}
+	checkPointIndex(t, "inserted", inserted, entries, r)
+}
+
+func TestQuadTreeMatchesLinearScan(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	entries := randomEntries(r, 500)
+	q, ok := NewQuadTree[int](NewRect(0, 0, 49, 49), 4)
+	if !ok {
+		t.Fatal("expected valid bounds to be accepted")
+	}
+	for _, entry := range entries {
+		if !q.Insert(entry.Point, entry.Value) {
+			t.Fatalf("expected %v to be inside the bounds", entry.Point)
+		}
+	}
+	checkPointIndex(t, "quadtree", q, entries, r)
+
+	if q.Insert(Point{X: 50, Y: 0}, -1) {
+		t.Fatal("expected points outside the bounds to be rejected")
+	}
+	if _, ok := NewQuadTree[int](Rect{Min: Point{X: 1}, Max: Point{}}, 4); ok {
+		t.Fatal("expected invalid bounds to be rejected")
+	}
+}
+
+func TestQuadTreeDuplicatePoints(t *testing.T) {
+	q, _ := NewQuadTree[int](NewRect(0, 0, 1, 1), 2)
+	for i := 0; i < 100; i++ {
+		q.Insert(Point{X: 0.5, Y: 0.5}, i)
+	}
+	nearest := q.KNearest(Point{}, 3)
+	if len(nearest) != 3 || nearest[0].Value != 0 || nearest[2].Value != 2 {
+		t.Fatalf("expected the first three duplicates in insertion order, got %v", nearest)
+	}
+}
+
+func TestRTreeMatchesLinearScan(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	tree := NewRTree[int](4)
+	boxes := make([]Box[int], 300)
+	for i := range boxes {
+		x, y := r.Float64()*100, r.Float64()*100
+		boxes[i] = Box[int]{Rect: NewRect(x, y, x+r.Float64()*10, y+r.Float64()*10), Value: i}
+		tree.Insert(boxes[i].Rect, i)
+	}
+	if tree.Len() != len(boxes) {
+		t.Fatalf("expected %d boxes, got %d", len(boxes), tree.Len())
+	}
+
+	for query := 0; query < 100; query++ {
+		window := NewRect(r.Float64()*100, r.Float64()*100, r.Float64()*100, r.Float64()*100)
+		expected := []Box[int]{}
+		for _, box := range boxes {
+			if box.Rect.Intersects(window) {
+				expected = append(expected, box)
+			}
+		}
+		if got := tree.Search(window); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("expected %d boxes intersecting %v, got %d", len(expected), window, len(got))
+		}
+
+		p := Point{X: r.Float64() * 110, Y: r.Float64() * 110}
+		sorted := append([]Box[int](nil), boxes...)
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Rect.distance2(p) < sorted[j].Rect.distance2(p) })
+		if got := tree.KNearest(p, 5); !reflect.DeepEqual(got, sorted[:5]) {
+			t.Fatalf("expected 5 boxes nearest to %v to be %v, got %v", p, sorted[:5], got)
+		}
+	}
+
+	if tree.Insert(Rect{Min: Point{X: 1}, Max: Point{}}, -1) {
+		t.Fatal("expected invalid rectangles to be rejected")
+	}
+}
+
+func TestRTreeSearchPoint(t *testing.T) {
+	tree := NewRTree[string](0)
+	tree.Insert(NewRect(0, 0, 10, 10), "park")
+	tree.Insert(NewRect(5, 5, 15, 15), "lake")
+	tree.Insert(NewRect(20, 20, 30, 30), "airport")
+
+	var names []string
+	for _, box := range tree.SearchPoint(Point{X: 7, Y: 7}) {
+		names = append(names, box.Value)
+	}
+	if !reflect.DeepEqual(names, []string{"park", "lake"}) {
+		t.Fatalf("expected [park lake], got %v", names)
+	}
+	if box, ok := tree.Nearest(Point{X: 40, Y: 40}); !ok || box.Value != "airport" {
+		t.Fatalf("expected airport to be nearest, got %v", box)
+	}
+}
+
+func TestEmptyIndexes(t *testing.T) {
+	q, _ := NewQuadTree[int](NewRect(0, 0, 1, 1), 0)
+	for name, index := range map[string]pointIndex{"kdtree": NewKDTree[int](nil), "quadtree": q} {
+		if _, ok := index.Nearest(Point{}); ok {
+			t.Fatalf("%s: expected no nearest point in an empty index", name)
+		}
+		if len(index.KNearest(Point{}, 3)) != 0 || len(index.Range(NewRect(0, 0, 1, 1))) != 0 {
+			t.Fatalf("%s: expected empty results", name)
+		}
+	}
+	if _, ok := NewRTree[int](0).Nearest(Point{}); ok {
+		t.Fatal("expected no nearest box in an empty R-tree")
+	}
+	if got := NewKDTree([]Entry[int]{{Point: Point{X: math.NaN()}}}); got.Len() != 0 {
+		t.Fatal("expected NaN points to be skipped")
+	}
+}
+
+func TestFromGraph(t *testing.T) {
+	g, _ := graph.GridGraph(20, 30, graph.GeneratorOptions{})
+	g.AddVertex("depot") // no coordinates
+	index := FromGraph(g, graph.GridCoordinates)
+	if index.Len() != 600 {
+		t.Fatalf("expected 600 indexed vertices, got %d", index.Len())
+	}
+
+	start, ok := index.Nearest(Point{X: 3.4, Y: 7.6})
+	if !ok || start.Value != "8,3" {
+		t.Fatalf("expected vertex 8,3 (row 8, column 3), got %v", start)
+	}
+	if _, cost, ok := g.AStar(start.Value, "19,29", graph.ManhattanHeuristic(graph.GridCoordinates)); !ok || cost != 37 {
+		t.Fatalf("expected a path of cost 37 from the snapped vertex, got %d", cost)
+	}
+
+	// (0.5, 0.5) is equally far from four vertices; the lowest ID wins.
+	if nearest, _ := index.Nearest(Point{X: 0.5, Y: 0.5}); nearest.Value != "0,0" {
+		t.Fatalf("expected the tie to go to 0,0, got %s", nearest.Value)
+	}
+	if FromGraph(g, nil).Len() != 0 {
+		t.Fatal("expected a nil extractor to give an empty index")
+	}
+}